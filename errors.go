@@ -17,4 +17,16 @@ var (
 	ErrScopedOnRootContainer     = errors.New("scoped lifetime services cannot be retrieved directly from root container, please use Scope") // New Scoped error
 	ErrTransientInstance         = errors.New("instance registration does not support Transient lifetime, please use Singleton or Scoped")
 	ErrNilInstance               = errors.New("registered instance cannot be nil")
+	ErrInvalidInitMethod         = errors.New("init method must exist on the implementation type and may only return error")
+	ErrInstanceNotAssignable     = errors.New("registered instance is not assignable to the declared target type")
+	ErrContextRequired           = errors.New("constructor requires context.Context but none was supplied (use ResolveContext) or registered")
+	ErrAmbiguousResolution       = errors.New("multiple implementations registered for the type, cannot resolve a single one")
+	ErrArrayLengthMismatch       = errors.New("number of collected services does not match the array length")
+	ErrCaptiveDependency         = errors.New("singleton service depends on a scoped service (captive dependency)")
+	ErrInvalidateNotSupported    = errors.New("only constructor-backed singletons can be invalidated")
+	ErrNoImplementation          = errors.New("no registered service implements the interface")
+	ErrNilInterfaceInstance      = errors.New("interface service is registered but its instance is nil")
+	ErrResolveCanceled           = errors.New("resolution canceled or timed out by its context")
+	ErrInvokeFailed              = errors.New("failed to resolve the parameters of the invoked function")
+	ErrFreshNotSupported         = errors.New("pre-registered instances cannot be constructed fresh")
 )
@@ -25,6 +25,18 @@ func TestErrorConstants(t *testing.T) {
 		{"ErrScopedOnRootContainer", ErrScopedOnRootContainer, false},
 		{"ErrTransientInstance", ErrTransientInstance, false},
 		{"ErrNilInstance", ErrNilInstance, false},
+		{"ErrInvalidInitMethod", ErrInvalidInitMethod, false},
+		{"ErrInstanceNotAssignable", ErrInstanceNotAssignable, false},
+		{"ErrContextRequired", ErrContextRequired, false},
+		{"ErrAmbiguousResolution", ErrAmbiguousResolution, false},
+		{"ErrArrayLengthMismatch", ErrArrayLengthMismatch, false},
+		{"ErrCaptiveDependency", ErrCaptiveDependency, false},
+		{"ErrInvalidateNotSupported", ErrInvalidateNotSupported, false},
+		{"ErrNoImplementation", ErrNoImplementation, false},
+		{"ErrNilInterfaceInstance", ErrNilInterfaceInstance, false},
+		{"ErrResolveCanceled", ErrResolveCanceled, false},
+		{"ErrInvokeFailed", ErrInvokeFailed, false},
+		{"ErrFreshNotSupported", ErrFreshNotSupported, false},
 	}
 
 	for _, tt := range errorTests {
@@ -52,6 +64,18 @@ func TestErrorMessages(t *testing.T) {
 		ErrScopedOnRootContainer,
 		ErrTransientInstance,
 		ErrNilInstance,
+		ErrInvalidInitMethod,
+		ErrInstanceNotAssignable,
+		ErrContextRequired,
+		ErrAmbiguousResolution,
+		ErrArrayLengthMismatch,
+		ErrCaptiveDependency,
+		ErrInvalidateNotSupported,
+		ErrNoImplementation,
+		ErrNilInterfaceInstance,
+		ErrResolveCanceled,
+		ErrInvokeFailed,
+		ErrFreshNotSupported,
 	}
 
 	for _, err := range errorTests {
@@ -102,4 +126,16 @@ func TestErrorTypes(t *testing.T) {
 	var _ error = ErrScopedOnRootContainer
 	var _ error = ErrTransientInstance
 	var _ error = ErrNilInstance
+	var _ error = ErrInvalidInitMethod
+	var _ error = ErrInstanceNotAssignable
+	var _ error = ErrContextRequired
+	var _ error = ErrAmbiguousResolution
+	var _ error = ErrArrayLengthMismatch
+	var _ error = ErrCaptiveDependency
+	var _ error = ErrInvalidateNotSupported
+	var _ error = ErrNoImplementation
+	var _ error = ErrNilInterfaceInstance
+	var _ error = ErrResolveCanceled
+	var _ error = ErrInvokeFailed
+	var _ error = ErrFreshNotSupported
 }
@@ -1,43 +1,229 @@
 package gofac
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ServiceDef Service definition: stores registration metadata, cached parameter types, and singleton instances
 type ServiceDef struct {
-	implType   reflect.Type   // Service implementation type (constructor return value or instance type)
-	scope      LifetimeScope  // Lifetime scope
-	instance   reflect.Value  // Singleton instance cache or pre-registered instance
-	ctor       reflect.Value  // Constructor reflection value (empty for instance registration)
-	ctorType   reflect.Type   // Constructor reflection type (empty for instance registration)
-	once       sync.Once      // Atomic operation for singleton instance initialization
-	paramTypes []reflect.Type // Cached constructor parameter types (core optimization)
-	paramOnce  sync.Once      // Ensures parameter types are parsed only once (concurrency-safe)
-	isInstance bool           // Whether this is an instance registration (if true, use instance directly without calling ctor)
+	implType   reflect.Type                            // Service implementation type (constructor return value or instance type)
+	scope      LifetimeScope                           // Lifetime scope
+	instance   reflect.Value                           // Singleton instance cache or pre-registered instance
+	ctor       reflect.Value                           // Constructor reflection value (empty for instance registration)
+	ctorType   reflect.Type                            // Constructor reflection type (empty for instance registration)
+	once       sync.Once                               // Atomic operation for singleton instance initialization
+	paramTypes []reflect.Type                          // Cached constructor parameter types (core optimization)
+	paramOnce  sync.Once                               // Ensures parameter types are parsed only once (concurrency-safe)
+	isInstance bool                                    // Whether this is an instance registration (if true, use instance directly without calling ctor)
+	initMethod *reflect.Method                         // Optional post-construction init method (method injection), nil if not configured
+	factory    func(*Container) (reflect.Value, error) // Factory closure (RegisterFactory), used instead of ctor when set
+	name       string                                  // Registration name (empty for default registrations), injected into ServiceName parameters
+	priority   int                                     // Ordering priority for ResolveAll (ascending, default 0)
+	order      int                                     // Registration sequence number, keeps ResolveAll stable for equal priorities
+	primary    bool                                    // Preferred implementation for single resolution among several (Primary)
+	resolves   atomic.Int64                            // Resolution count while stats are enabled (EnableStats)
+	cleanup    func()                                  // Cleanup of an instance registration run by Container.Close (RegisterInstanceWithCleanup)
+	lifetimeFn func() LifetimeScope                    // Lifetime chosen per resolution (RegisterDynamic), nil for a fixed scope
+	fallible   bool                                    // Constructor returns (T, error), a non-nil error fails construction (internal wrappers only)
+	internal   bool                                    // Synthetic registration (RegisterMulti result tuple), hidden from graphs and static checks
+	via        *ServiceDef                             // Registration this one is derived from (RegisterMulti result), whose dependencies it reports
+	retry      *retryPolicy                            // Retries of a fallible constructor's errors (RegisterWithRetry), nil for a single call
 }
 
+// retryPolicy How often a fallible constructor is called before its error fails the resolution (RegisterWithRetry)
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// RegisterOption Optional registration setting (e.g. WithPriority)
+type RegisterOption func(*ServiceDef)
+
+// WithPriority Registration option: ResolveAll returns services sorted ascending by priority (stable for ties), default 0
+func WithPriority(priority int) RegisterOption {
+	return func(d *ServiceDef) { d.priority = priority }
+}
+
+// Primary Registration option for RegisterAs and RegisterImplementation: marks the registration returned when a single
+// instance of the interface is resolved while several are registered (collections still return all of them); at most
+// one per interface, shared by the default registration and the additional implementations
+func Primary() RegisterOption {
+	return func(d *ServiceDef) { d.primary = true }
+}
+
+// applyRegisterOptions Applies registration options to a service definition
+func applyRegisterOptions(serviceDef *ServiceDef, opts []RegisterOption) {
+	for _, opt := range opts {
+		opt(serviceDef)
+	}
+}
+
+// resolveTrack Per-call resolution state: types on the current dependency chain (circular dependency detection)
+// and the context supplied by the caller (nil when resolving without a context)
+type resolveTrack struct {
+	visiting  map[reflect.Type]bool
+	ctx       context.Context
+	trace     *Trace                             // Node being resolved while tracing (ResolveTraced), nil otherwise
+	resolvers []*inflightResolver                // Resolver views injected into constructors during this call, detached on release
+	partial   map[reflect.Type]*partialSingleton // Pre-allocated singletons under construction (AllowPointerCycles)
+	info      ResolutionInfo                     // Service being constructed, injected into ResolutionInfo parameters
+}
+
+// partialSingleton Zero *T allocated before a pointer singleton's constructor runs (AllowPointerCycles): handed to
+// dependencies that resolve the singleton again, then filled with the constructed value
+type partialSingleton struct {
+	ptr        reflect.Value
+	used       bool                         // Whether a dependency received ptr, i.e. the constructed value must be copied into it
+	dependents map[reflect.Type]*ServiceDef // Default singletons cached during construction, dropped if it fails
+}
+
+// trackPool Reuses resolution state across top-level resolve calls to cut per-resolve allocations
+var trackPool = sync.Pool{
+	New: func() any {
+		return &resolveTrack{visiting: make(map[reflect.Type]bool)}
+	},
+}
+
+// newResolveTrack Takes resolution state for one top-level resolve call from the pool; the caller must release it
+// once the call returns (the same state is shared by the whole recursive resolution, never across concurrent calls)
+func newResolveTrack(ctx context.Context) *resolveTrack {
+	track := trackPool.Get().(*resolveTrack)
+	track.ctx = ctx
+	return track
+}
+
+// release Clears the resolution state and returns it to the pool
+func (t *resolveTrack) release() {
+	clear(t.visiting)
+	clear(t.partial)
+	t.info = ResolutionInfo{}
+	t.ctx = nil
+	t.trace = nil
+	for _, r := range t.resolvers {
+		r.detach()
+	}
+	clear(t.resolvers)
+	t.resolvers = t.resolvers[:0]
+	trackPool.Put(t)
+}
+
+// beginBuild Records the service about to be constructed for ResolutionInfo parameters and returns the outer one, which
+// the caller restores once construction has finished
+func (t *resolveTrack) beginBuild(info ResolutionInfo) ResolutionInfo {
+	outer := t.info
+	t.info = info
+	return outer
+}
+
+// ctxErr Returns the resolution context's error (nil when no context was supplied)
+func (t *resolveTrack) ctxErr() error {
+	if t.ctx == nil {
+		return nil
+	}
+	return t.ctx.Err()
+}
+
+// sleep Waits for d, returning the context error early if the resolution context is done first
+func (t *resolveTrack) sleep(d time.Duration) error {
+	if t.ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	}
+}
+
+// ServiceName Special constructor parameter type: the resolver fills it with the service's own registration name
+// (empty string for default registrations) instead of resolving it from the container, e.g. to label metrics
+type ServiceName string
+
+// ResolutionInfo Special constructor parameter type: the resolver fills it with metadata about the resolution building
+// the service instead of resolving it from the container, so a service can describe how it was created (diagnostics)
+type ResolutionInfo struct {
+	Type     reflect.Type  // Requested service type (the implementation type for named, keyed, implementation and group registrations)
+	Lifetime LifetimeScope // Lifetime the instance is built with
+	Name     string        // Registration name, empty for default registrations
+	InScope  bool          // Whether the instance is built by a Scope resolution rather than on the root container
+}
+
+var (
+	serviceNameType    = reflect.TypeOf(ServiceName(""))
+	resolutionInfoType = reflect.TypeOf(ResolutionInfo{})
+	contextType        = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
 // Container DI container core: manages all services with concurrency safety
 type Container struct {
-	services      map[reflect.Type]*ServiceDef            // Default (unnamed) services
-	namedServices map[string]map[reflect.Type]*ServiceDef // Named services: name -> type -> ServiceDef
-	mu            sync.RWMutex
+	services        map[reflect.Type]*ServiceDef            // Default (unnamed) services
+	namedServices   map[string]map[reflect.Type]*ServiceDef // Named services: name -> type -> ServiceDef
+	keyedServices   map[any]*ServiceDef                     // Keyed services: Key[T] identity -> ServiceDef
+	implementations map[reflect.Type][]*ServiceDef          // Additional unnamed implementations per interface (RegisterImplementation), in registration order
+	groups          map[string][]*ServiceDef                // Value groups (RegisterGroup): group name -> members in registration order
+	registered      int                                     // Registration counter, source of ServiceDef.order
+	autoDeref       bool                                    // Whether unregistered T/*T may be resolved from a registered *T/T counterpart
+	strictTypes     bool                                    // Whether resolution forbids ConvertibleTo adaptation (assignable types only)
+	nilEmptySlices  bool                                    // Whether auto-collected slices without matches are nil instead of empty
+	requireImpls    bool                                    // Whether auto-collecting a slice of interfaces nothing implements fails (RequireImplementations)
+	underlying      bool                                    // Whether unregistered defined types and their underlying types may satisfy each other
+	implementsScan  bool                                    // Whether an unregistered interface resolves from the single default registration implementing it
+	mergeSlices     bool                                    // Whether a registered slice parameter also receives the auto-collected elements (MergeRegisteredSlices)
+	mapDefault      bool                                    // Whether auto-collected map[string]T include the default registration of T (SetMapDefaultKey)
+	mapDefaultKey   string                                  // Key of the default registration in auto-collected maps
+	nameMatcher     func(a, b string) bool                  // Decides whether two service names are the same (SetNameMatcher), nil for exact matching
+	pointerCycles   bool                                    // Whether cycles between pointer singletons are completed with pre-allocated instances (AllowPointerCycles)
+	mustHandler     func(error)                             // Replaces the panic of failed Must* calls (SetMustHandler), nil to panic
+	implementsCache map[reflect.Type]map[reflect.Type]bool  // EnableImplementsScan: interface -> default concrete registrations implementing it, dropped on registration
+	implTypeIndex   bool                                    // Whether a concrete type resolves from the interface registration it implements (IndexImplTypes)
+	implTypeCache   map[reflect.Type][]reflect.Type         // IndexImplTypes: implementation type -> interfaces it is registered as, dropped on registration
+	scanMu          sync.Mutex                              // Guards implementsCache and implTypeCache (filled while holding only c.mu's read lock)
+	firstInit       map[reflect.Type][]func(any)            // OnFirstInit callbacks: service type -> callbacks fired once when the singleton is built
+	namedScopes     map[string]*Scope                       // Persistent scopes by tag (NamedScope), e.g. one per tenant
+	fallback        FallbackFunc                            // Provider consulted for unregistered types (SetFallback), nil by default
+	singletonStore  SingletonStore                          // External storage of default singletons (SetSingletonStore), nil to keep them in memory only
+	transforms      []resolveTransform                      // OnResolveTransform hooks in registration order
+	stats           atomic.Bool                             // Whether resolutions are counted per registration (EnableStats)
+	parent          *Container                              // Parent of a child container (NewChild), nil for root containers
+	mu              sync.RWMutex
+	started         []Lifecycle // Lifecycle services started by Start, stopped in reverse order by Stop
+	running         bool        // Whether Start succeeded and Stop has not been called since (guarded by lifecycleMu)
+	lifecycleMu     sync.Mutex  // Guards started (separate from mu since Start resolves services)
 }
 
 // Scope Within the same Scope, Scoped instances are unique; different Scopes are isolated from each other
 type Scope struct {
 	root       *Container                     // Associated root container (shares registration metadata)
 	scopedInst map[reflect.Type]reflect.Value // Scoped instance cache for this scope
+	inherited  map[reflect.Type]bool          // Scoped instances copied from a parent scope by Clone (owned by the parent, not this scope)
+	overrides  map[reflect.Type]reflect.Value // Scope-local instances shadowing root registrations (Override/OverrideAs)
+	scopedDefs map[*ServiceDef]reflect.Value  // Scoped instances of named/implementation registrations (one per name+type registration)
+	parent     *Scope                         // Enclosing scope of a nested scope (Scope.NewScope), nil for top-level scopes
+	cancel     context.CancelFunc             // Ends the context of a NewScopeContext scope (stops its watcher), nil otherwise
+	ctxDone    atomic.Bool                    // Whether a NewScopeContext scope has been disposed (by its watcher or manually)
 	mu         sync.RWMutex                   // Scope concurrency-safe lock
 }
 
 // NewContainer Creates a new DI container
 func NewContainer() *Container {
 	return &Container{
-		services:      make(map[reflect.Type]*ServiceDef),
-		namedServices: make(map[string]map[reflect.Type]*ServiceDef),
+		services:        make(map[reflect.Type]*ServiceDef),
+		namedServices:   make(map[string]map[reflect.Type]*ServiceDef),
+		keyedServices:   make(map[any]*ServiceDef),
+		implementations: make(map[reflect.Type][]*ServiceDef),
+		groups:          make(map[string][]*ServiceDef),
 	}
 }
 
@@ -51,30 +237,112 @@ func (c *Container) Register(ctor any, scope LifetimeScope) error {
 	return c.register(ctor, nil, scope)
 }
 
-// RegisterAs Interface registration: registers implementation type as specified interface type, returns error (requires manual handling)
-func (c *Container) RegisterAs(ctor any, interfaceType any, scope LifetimeScope) error {
+// RegisterAs Interface registration: registers implementation type as specified interface type, returns error (requires manual handling).
+// Options such as WithPriority or Primary apply to the registration (at most one primary per interface, counting RegisterImplementation)
+func (c *Container) RegisterAs(ctor any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.register(ctor, interfaceType, scope, opts...)
+}
+
+// RegisterDynamic Registers a constructor whose lifetime is chosen by lifetimeFn on every resolution, e.g. Singleton in
+// production and Transient in tests behind a feature flag. Caveats: a singleton instance, once cached, stays cached and is
+// returned again whenever lifetimeFn yields Singleton (use Invalidate or ClearCaches to drop it); static analysis
+// (Validate, graphs) sees the lifetime lifetimeFn returned at registration
+func (c *Container) RegisterDynamic(ctor any, lifetimeFn func() LifetimeScope) error {
+	if lifetimeFn == nil {
+		return fmt.Errorf("%w, lifetimeFn must not be nil", ErrNotFunc)
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return c.register(ctor, interfaceType, scope)
+	if err := c.register(ctor, nil, lifetimeFn()); err != nil {
+		return err
+	}
+	c.services[reflect.TypeOf(ctor).Out(0)].lifetimeFn = lifetimeFn
+	return nil
+}
+
+// lifetime Lifetime to apply to the current resolution: lifetimeFn's choice for RegisterDynamic, otherwise scope
+func (d *ServiceDef) lifetime() LifetimeScope {
+	if d.lifetimeFn != nil {
+		return d.lifetimeFn()
+	}
+	return d.scope
 }
 
 // register Internal common registration logic, extracts duplicate code
-func (c *Container) register(ctor any, interfaceType any, scope LifetimeScope) error {
+func (c *Container) register(ctor any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) error {
+	svcType, serviceDef, err := newCtorServiceDef(ctor, interfaceType, scope)
+	if err != nil {
+		return err
+	}
+	applyRegisterOptions(serviceDef, opts)
+	return c.addDefaultLocked(svcType, serviceDef, interfaceType == nil)
+}
+
+// addDefaultLocked Adds the default registration of svcType after the duplicate and primary checks; with outFields an
+// Out result struct also registers each of its exported fields (caller holds c.mu)
+func (c *Container) addDefaultLocked(svcType reflect.Type, serviceDef *ServiceDef, outFields bool) error {
+	// Check for duplicate registration
+	if existing, exists := c.services[svcType]; exists {
+		return duplicateError(svcType, existing, serviceDef.implType)
+	}
+	if err := c.checkPrimaryLocked(svcType, serviceDef); err != nil {
+		return err
+	}
+
+	// Out result struct: each exported field is registered as its own service as well
+	if outFields && isOutStruct(svcType) {
+		if err := c.registerOutFields(svcType, serviceDef.scope); err != nil {
+			return err
+		}
+	}
+
+	// Add service definition to container
+	serviceDef.order = c.nextOrder()
+	c.services[svcType] = serviceDef
+	return nil
+}
+
+// duplicateError ErrRegisterDuplicate for a second default registration of svcType. An interface that is already taken
+// names both implementations and suggests the registrations that let them coexist
+func duplicateError(svcType reflect.Type, existing *ServiceDef, implType reflect.Type) error {
+	if svcType.Kind() != reflect.Interface {
+		return fmt.Errorf("%w, type: %s", ErrRegisterDuplicate, svcType)
+	}
+	return fmt.Errorf("%w, type: %s, registered implementation: %s, new implementation: %s "+
+		"(register one of them by name with RegisterNamed/ContainerProvideNamed/RegisterInstanceAsNamed, or enable several implementations with RegisterImplementation)",
+		ErrRegisterDuplicate, svcType, existing.implType, implType)
+}
+
+// newCtorServiceDef Validates a constructor and builds its service definition, returns the final registered service type
+func newCtorServiceDef(ctor any, interfaceType any, scope LifetimeScope) (reflect.Type, *ServiceDef, error) {
+	return newFuncServiceDef(ctor, interfaceType, scope, false)
+}
+
+// newFuncServiceDef Shared by newCtorServiceDef and the internal constructor wrappers: with fallible the function must
+// return (T, error) instead of exactly T
+func newFuncServiceDef(ctor any, interfaceType any, scope LifetimeScope, fallible bool) (reflect.Type, *ServiceDef, error) {
 	// Parse constructor reflection information
 	ctorVal := reflect.ValueOf(ctor)
 	ctorType := ctorVal.Type()
 	if ctorType.Kind() != reflect.Func {
-		return ErrNotFunc
+		return nil, nil, ErrNotFunc
 	}
 
-	// Validate constructor return value: only 1 return value, and must be concrete type
+	// Validate constructor return value: only 1 return value (plus the error of a fallible one), and must be concrete type
 	numOut := ctorType.NumOut()
-	if numOut != 1 {
-		return fmt.Errorf("%w, current return value count: %d", ErrNoReturn, numOut)
+	if fallible {
+		if numOut != 2 || ctorType.Out(1) != errorType {
+			return nil, nil, fmt.Errorf("%w, expected (T, error) results, current return value count: %d", ErrNoReturn, numOut)
+		}
+	} else if numOut != 1 {
+		return nil, nil, fmt.Errorf("%w, current return value count: %d", ErrNoReturn, numOut)
 	}
 	implType := ctorType.Out(0)
-	if implType.Kind() == reflect.Interface {
-		return fmt.Errorf("%w, return value is interface: %s", ErrNotConcreteType, implType)
+	// Interface return values are only allowed with an explicit target interface (RegisterAs), checked below
+	if implType.Kind() == reflect.Interface && interfaceType == nil {
+		return nil, nil, fmt.Errorf("%w, return value is interface: %s", ErrNotConcreteType, implType)
 	}
 
 	// Determine final registered service type (interface/implementation type)
@@ -85,7 +353,7 @@ func (c *Container) register(ctor any, interfaceType any, scope LifetimeScope) e
 
 		// Check if it's a pointer type
 		if targetType.Kind() != reflect.Ptr {
-			return ErrInvalidInterfaceType
+			return nil, nil, ErrInvalidInterfaceType
 		}
 
 		// Get the element type pointed to by the pointer
@@ -94,172 +362,218 @@ func (c *Container) register(ctor any, interfaceType any, scope LifetimeScope) e
 		// Determine if it points to an interface or concrete type
 		if elemType.Kind() == reflect.Interface {
 			// Interface type: use interface type as service type
+			// (an interface return value that is or embeds the target interface passes this check)
 			svcType = elemType
 			if !implType.Implements(svcType) {
-				return fmt.Errorf("type %s does not implement interface %s", implType, svcType)
+				return nil, nil, fmt.Errorf("type %s does not implement interface %s", implType, svcType)
 			}
 		} else {
+			// Concrete type target requires a concrete constructor return value
+			if implType.Kind() == reflect.Interface {
+				return nil, nil, fmt.Errorf("%w, return value is interface: %s", ErrNotConcreteType, implType)
+			}
 			// Concrete type: use complete pointer type as service type
 			// Example: (*UserService)(nil) -> register as *UserService type
 			svcType = targetType
 			// Enhanced type compatibility check, supports pointer/value type conversion
 			if !isTypeCompatible(implType, svcType) {
-				return fmt.Errorf("type %s cannot be converted to target type %s", implType, svcType)
+				return nil, nil, fmt.Errorf("type %s cannot be converted to target type %s", implType, svcType)
 			}
 		}
 	}
 
-	// Check for duplicate registration
-	if _, exists := c.services[svcType]; exists {
-		return fmt.Errorf("%w, type: %s", ErrRegisterDuplicate, svcType)
+	// Reject direct self-reference: a parameter of the constructor's own return/registered type can never be satisfied
+	for i := 0; i < ctorType.NumIn(); i++ {
+		if pType := ctorType.In(i); pType == implType || pType == svcType {
+			return nil, nil, fmt.Errorf("%w, constructor parameter %d depends on its own type: %s", ErrResolveCircularDependency, i, pType)
+		}
 	}
 
-	// Encapsulate service definition and add to container
-	c.services[svcType] = &ServiceDef{
+	// Encapsulate service definition
+	return svcType, &ServiceDef{
 		implType:   implType,
 		scope:      scope,
 		ctor:       ctorVal,
 		ctorType:   ctorType,
 		isInstance: false,
-	}
-	return nil
-}
-
-// RegisterInstance Instance registration: directly registers a created instance, registers by instance type
-// Note: Does not support Transient lifetime (instance already created, cannot return new instance each time)
-func (c *Container) RegisterInstance(instance any, scope LifetimeScope) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return c.registerInstance(instance, nil, scope)
+		fallible:   fallible,
+	}, nil
 }
 
-// RegisterInstanceAs Instance interface registration: registers a created instance as specified interface type
-// Note: Does not support Transient lifetime (instance already created, cannot return new instance each time)
-func (c *Container) RegisterInstanceAs(instance any, interfaceType any, scope LifetimeScope) error {
+// RegisterImplementation Registers an additional unnamed implementation of an interface (unlike RegisterAs, several
+// may coexist). ResolveAll and slice injection/Get[[]Iface] collect all of them; resolving a single Iface returns the
+// registration marked Primary() (RegisterAs or implementation), else the only registration, and ErrAmbiguousResolution
+// if a RegisterAs registration and implementations (or several implementations) coexist without a primary
+func (c *Container) RegisterImplementation(ctor any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return c.registerInstance(instance, interfaceType, scope)
+	svcType, serviceDef, err := newCtorServiceDef(ctor, interfaceType, scope)
+	if err != nil {
+		return err
+	}
+	if svcType.Kind() != reflect.Interface {
+		return ErrInvalidInterfaceType
+	}
+	applyRegisterOptions(serviceDef, opts)
+	return c.addImplementation(svcType, serviceDef)
 }
 
-// registerInstance Internal instance registration logic
-func (c *Container) registerInstance(instance any, interfaceType any, scope LifetimeScope) error {
-	// Transient does not support instance registration (cannot create new instance each time)
+// RegisterInstanceImplementation Instance version of RegisterImplementation (Transient not supported)
+func (c *Container) RegisterInstanceImplementation(instance any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) error {
 	if scope == Transient {
 		return ErrTransientInstance
 	}
-
-	// Validate instance is not nil
-	if instance == nil {
+	if isNilInstance(instance) {
 		return ErrNilInstance
 	}
-
 	instVal := reflect.ValueOf(instance)
-	implType := instVal.Type()
-
-	// Determine final registered service type (interface/implementation type)
-	svcType := implType
-	if interfaceType != nil {
-		// Parse target type
-		targetType := reflect.TypeOf(interfaceType)
+	svcType, err := instanceServiceType(instVal.Type(), interfaceType)
+	if err != nil {
+		return err
+	}
+	if svcType.Kind() != reflect.Interface {
+		return ErrInvalidInterfaceType
+	}
 
-		// Check if it's a pointer type
-		if targetType.Kind() != reflect.Ptr {
-			return ErrInvalidInterfaceType
-		}
+	serviceDef := &ServiceDef{
+		implType:   instVal.Type(),
+		scope:      scope,
+		instance:   addressable(instVal),
+		isInstance: true,
+	}
+	applyRegisterOptions(serviceDef, opts)
 
-		// Get the element type pointed to by the pointer
-		elemType := targetType.Elem()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.addImplementation(svcType, serviceDef)
+}
 
-		// Determine if it points to an interface or concrete type
-		if elemType.Kind() == reflect.Interface {
-			// Interface type: use interface type as service type
-			svcType = elemType
-			if !implType.Implements(svcType) {
-				return fmt.Errorf("instance type %s does not implement interface %s", implType, svcType)
-			}
-		} else {
-			// Concrete type: use complete pointer type as service type
-			// Example: (*UserService)(nil) -> register as *UserService type
-			svcType = targetType
-			// Enhanced type compatibility check, supports pointer/value type conversion
-			if !isTypeCompatible(implType, svcType) {
-				return fmt.Errorf("instance type %s cannot be converted to target type %s", implType, svcType)
-			}
-		}
+// addImplementation Appends an implementation, allowing at most one primary per interface (caller holds c.mu)
+func (c *Container) addImplementation(svcType reflect.Type, serviceDef *ServiceDef) error {
+	if err := c.checkPrimaryLocked(svcType, serviceDef); err != nil {
+		return err
 	}
+	serviceDef.order = c.nextOrder()
+	c.implementations[svcType] = append(c.implementations[svcType], serviceDef)
+	return nil
+}
 
-	// Check for duplicate registration
-	if _, exists := c.services[svcType]; exists {
-		return fmt.Errorf("%w, type: %s", ErrRegisterDuplicate, svcType)
+// checkPrimaryLocked Rejects a second primary for svcType: the default registration (RegisterAs) and the additional
+// implementations share one primary (caller holds c.mu)
+func (c *Container) checkPrimaryLocked(svcType reflect.Type, serviceDef *ServiceDef) error {
+	if !serviceDef.primary {
+		return nil
 	}
-
-	// Encapsulate service definition and add to container
-	c.services[svcType] = &ServiceDef{
-		implType:   implType,
-		scope:      scope,
-		instance:   instVal,
-		isInstance: true,
+	if def, exists := c.services[svcType]; exists && def.primary {
+		return fmt.Errorf("%w, primary implementation of %s already registered: %s", ErrRegisterDuplicate, svcType, def.implType)
+	}
+	for _, def := range c.implementations[svcType] {
+		if def.primary {
+			return fmt.Errorf("%w, primary implementation of %s already registered: %s", ErrRegisterDuplicate, svcType, def.implType)
+		}
 	}
 	return nil
 }
 
-// RegisterInstanceNamed Named instance registration: registers an instance with a name, allows multiple instances of the same type
-func (c *Container) RegisterInstanceNamed(name string, instance any, scope LifetimeScope) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return c.registerInstanceNamed(name, instance, nil, scope)
+// registration Returns the registration resolving svcType by type (registrationLocked); ok is false if there is none
+func (c *Container) registration(svcType reflect.Type) (*ServiceDef, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	def, err := c.registrationLocked(svcType)
+	return def, def != nil, err
 }
 
-// RegisterInstanceAsNamed Named instance interface registration: registers an instance with a name as specified type
-func (c *Container) RegisterInstanceAsNamed(name string, instance any, interfaceType any, scope LifetimeScope) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	return c.registerInstanceNamed(name, instance, interfaceType, scope)
+// registrationLocked The registration resolving svcType by type: the primary among the default registration (RegisterAs)
+// and the additional implementations, else the only one of them; nil if there is none, ErrAmbiguousResolution if there
+// are several without a primary (caller holds c.mu)
+func (c *Container) registrationLocked(svcType reflect.Type) (*ServiceDef, error) {
+	candidates := c.implementations[svcType]
+	if def, exists := c.services[svcType]; exists {
+		if len(candidates) == 0 {
+			return def, nil
+		}
+		candidates = append([]*ServiceDef{def}, candidates...)
+	}
+	for _, def := range candidates {
+		if def.primary {
+			return def, nil
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return candidates[0], nil
+	}
+	names := make([]string, len(candidates))
+	for i, def := range candidates {
+		names[i] = def.implType.String()
+	}
+	return nil, fmt.Errorf("%w, type: %s, candidates: [%s] (use Primary(), ResolveNamed, ResolveAll or a slice)", ErrAmbiguousResolution, svcType, strings.Join(names, ", "))
 }
 
-// registerInstanceNamed Internal named instance registration logic
-func (c *Container) registerInstanceNamed(name string, instance any, interfaceType any, scope LifetimeScope) error {
-	// Transient does not support instance registration
-	if scope == Transient {
-		return ErrTransientInstance
+// implementationValues Resolves the additional implementations registered under interface types implementing elemType
+// (sorted by interface type name, then registration order); Scoped constructors are skipped since they cannot be
+// cached per scope alongside one another
+func (c *Container) implementationValues(elemType reflect.Type, track *resolveTrack) ([]reflect.Value, error) {
+	if elemType.Kind() != reflect.Interface {
+		return nil, nil
 	}
+	c.mu.RLock()
+	ifaces := make([]reflect.Type, 0)
+	for t := range c.implementations {
+		if implementsInterface(t, elemType) {
+			ifaces = append(ifaces, t)
+		}
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].String() < ifaces[j].String() })
+	defs := make([]*ServiceDef, 0)
+	for _, t := range ifaces {
+		defs = append(defs, c.implementations[t]...)
+	}
+	c.mu.RUnlock()
 
-	// Validate instance is not nil
-	if instance == nil {
-		return ErrNilInstance
+	values := make([]reflect.Value, 0, len(defs))
+	for _, def := range defs {
+		if scopedOnly(def) {
+			continue
+		}
+		inst, err := c.resolveDef(def, track)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve implementation %s: %w", def.implType, err)
+		}
+		conv, err := convertInstance(elemType, inst, false)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, conv)
 	}
+	return values, nil
+}
+
+// RegisterNamed Named constructor registration: registers a constructor under a name, allows multiple services of the same type.
+// A ServiceName parameter of the constructor receives the registration name.
+func (c *Container) RegisterNamed(name string, ctor any, scope LifetimeScope, opts ...RegisterOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerNamed(name, ctor, nil, scope, opts)
+}
 
+// registerNamed Internal named constructor registration logic
+func (c *Container) registerNamed(name string, ctor any, interfaceType any, scope LifetimeScope, opts []RegisterOption) error {
 	// Validate name is not empty
 	if name == "" {
 		return fmt.Errorf("name cannot be empty for named registration")
 	}
 
-	instVal := reflect.ValueOf(instance)
-	implType := instVal.Type()
-
-	// Determine final registered service type
-	svcType := implType
-	if interfaceType != nil {
-		targetType := reflect.TypeOf(interfaceType)
-		if targetType.Kind() != reflect.Ptr {
-			return ErrInvalidInterfaceType
-		}
-
-		elemType := targetType.Elem()
-		if elemType.Kind() == reflect.Interface {
-			svcType = elemType
-			if !implType.Implements(svcType) {
-				return fmt.Errorf("instance type %s does not implement interface %s", implType, svcType)
-			}
-		} else {
-			svcType = targetType
-			if !isTypeCompatible(implType, svcType) {
-				return fmt.Errorf("instance type %s cannot be converted to target type %s", implType, svcType)
-			}
-		}
+	svcType, serviceDef, err := newCtorServiceDef(ctor, interfaceType, scope)
+	if err != nil {
+		return err
 	}
+	serviceDef.name = name
 
-	// Initialize named services map
+	// Initialize named services map (under the registered spelling of a matching name)
+	name = c.namedKeyLocked(name)
 	if c.namedServices[name] == nil {
 		c.namedServices[name] = make(map[reflect.Type]*ServiceDef)
 	}
@@ -269,56 +583,2269 @@ func (c *Container) registerInstanceNamed(name string, instance any, interfaceTy
 		return fmt.Errorf("%w, name: %s, type: %s", ErrRegisterDuplicate, name, svcType)
 	}
 
-	// Encapsulate service definition and add to container
-	c.namedServices[name][svcType] = &ServiceDef{
-		implType:   implType,
-		scope:      scope,
-		instance:   instVal,
-		isInstance: true,
-	}
+	serviceDef.order = c.nextOrder()
+	applyRegisterOptions(serviceDef, opts)
+	c.namedServices[name][svcType] = serviceDef
 	return nil
 }
 
-// isTypeCompatible Checks if two types are compatible (supports pointer/value type conversion)
-func isTypeCompatible(implType, targetType reflect.Type) bool {
-	// Directly assignable (including same type)
-	if implType.AssignableTo(targetType) {
-		return true
-	}
+// ProvideNamed Named constructor registration behind an interface on the global container, see ContainerProvideNamed
+func ProvideNamed[Iface any](name string, ctor any, scope LifetimeScope) error {
+	return ContainerProvideNamed[Iface](Global, name, ctor, scope)
+}
 
-	// Convertible
-	if implType.ConvertibleTo(targetType) {
-		return true
+// ContainerProvideNamed Registers a named constructor as interface Iface in one call (the common plugin-system case),
+// the constructor's return value must implement Iface
+func ContainerProvideNamed[Iface any](c *Container, name string, ctor any, scope LifetimeScope) error {
+	if reflect.TypeOf((*Iface)(nil)).Elem().Kind() != reflect.Interface {
+		return ErrInvalidInterfaceType
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerNamed(name, ctor, (*Iface)(nil), scope, nil)
+}
 
-	// Check pointer type compatibility: if implementation is value type, target is corresponding pointer type
-	if implType.Kind() != reflect.Ptr && reflect.PointerTo(implType).AssignableTo(targetType) {
-		return true
-	}
+// Key Typed registration token: an opaque comparable value bound to T at compile time, a safer alternative to
+// string names (passing a Key[*A] where a Key[*B] is expected does not compile). Create keys once with NewKey.
+type Key[T any] struct {
+	id *keyID
+}
 
-	// Check reverse pointer type compatibility: if implementation is pointer type, target is corresponding value type
-	if implType.Kind() == reflect.Ptr && implType.Elem().AssignableTo(targetType) {
-		return true
+type keyID struct {
+	name string // Description used in error messages only, keys with equal names are still distinct
+}
+
+// NewKey Creates a new unique key for T; name is only used for diagnostics
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{id: &keyID{name: name}}
+}
+
+// String Returns the key's diagnostic name
+func (k Key[T]) String() string {
+	if k.id == nil {
+		return "<zero key>"
 	}
+	return k.id.name
+}
 
-	return false
+// RegisterKeyed Keyed constructor registration on the global container, see ContainerRegisterKeyed
+func RegisterKeyed[T any](key Key[T], ctor any, scope LifetimeScope) error {
+	return ContainerRegisterKeyed(Global, key, ctor, scope)
 }
 
-// Resolve Original resolution: receives instance through pointer, returns error (compatible with old logic)
-func (c *Container) Resolve(out any) error {
-	outVal := reflect.ValueOf(out)
-	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
-		return ErrInvalidOutPtr
+// ContainerRegisterKeyed Registers a constructor under a typed key; the constructor's return value must be assignable to T
+func ContainerRegisterKeyed[T any](c *Container, key Key[T], ctor any, scope LifetimeScope) error {
+	if key.id == nil {
+		return fmt.Errorf("key must be created with NewKey")
 	}
-	svcType := outVal.Elem().Type()
-	instance, err := c.resolve(svcType, make(map[reflect.Type]bool))
+	svcType := reflect.TypeOf((*T)(nil)).Elem()
+	var interfaceType any
+	if svcType.Kind() == reflect.Interface {
+		interfaceType = (*T)(nil)
+	}
+	_, serviceDef, err := newCtorServiceDef(ctor, interfaceType, scope)
 	if err != nil {
 		return err
 	}
-	outVal.Elem().Set(instance)
+	if !serviceDef.implType.AssignableTo(svcType) {
+		return fmt.Errorf("type %s cannot be assigned to key type %s", serviceDef.implType, svcType)
+	}
+	serviceDef.name = key.String()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.keyedServices[key.id]; exists {
+		return fmt.Errorf("%w, key: %s, type: %s", ErrRegisterDuplicate, key, svcType)
+	}
+	serviceDef.order = c.nextOrder()
+	c.keyedServices[key.id] = serviceDef
+	return nil
+}
+
+// GetKeyed Keyed resolution on the global container, see ContainerGetKeyed
+func GetKeyed[T any](key Key[T]) (T, error) {
+	return ContainerGetKeyed(Global, key)
+}
+
+// ContainerGetKeyed Resolves the service registered under a typed key (Scoped keyed services are not supported on the root container)
+func ContainerGetKeyed[T any](c *Container, key Key[T]) (T, error) {
+	var zero T
+	c.mu.RLock()
+	serviceDef, exists := c.keyedServices[key.id]
+	c.mu.RUnlock()
+	if !exists {
+		return zero, fmt.Errorf("%w, key: %s", ErrServiceNotRegistered, key)
+	}
+	instance, err := c.resolveNamedDef(serviceDef)
+	if err != nil {
+		return zero, fmt.Errorf("[DI Get Failed] %w", err)
+	}
+	return getTyped[T](c, reflect.TypeOf((*T)(nil)).Elem(), instance)
+}
+
+// ResolveAsView View resolution on the global container, see ContainerResolveAsView
+func ResolveAsView[View any]() (View, error) {
+	return ContainerResolveAsView[View](Global)
+}
+
+// ContainerResolveAsView Resolution-time upcast: resolves the default registration implementing interface View (e.g. a
+// narrow ICacheReader over a registered *Cache) without registering it under View. A registration of View itself is
+// used directly; several implementing registrations are ambiguous
+func ContainerResolveAsView[View any](c *Container) (View, error) {
+	var zero View
+	viewType := reflect.TypeOf((*View)(nil)).Elem()
+	if viewType.Kind() != reflect.Interface {
+		return zero, ErrInvalidInterfaceType
+	}
+
+	svcType := viewType
+	if !c.isRegistered(viewType) {
+		c.mu.RLock()
+		candidates := make([]reflect.Type, 0, 1)
+		for t := range c.services {
+			if implementsInterface(t, viewType) {
+				candidates = append(candidates, t)
+			}
+		}
+		c.mu.RUnlock()
+		switch len(candidates) {
+		case 0:
+			return zero, fmt.Errorf("%w, no registration implements %s", ErrServiceNotRegistered, viewType)
+		case 1:
+			svcType = candidates[0]
+		default:
+			names := make([]string, len(candidates))
+			for i, t := range candidates {
+				names[i] = t.String()
+			}
+			sort.Strings(names)
+			return zero, fmt.Errorf("%w, view: %s, candidates: [%s]", ErrAmbiguousResolution, viewType, strings.Join(names, ", "))
+		}
+	}
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, err := c.resolve(svcType, track)
+	if err != nil {
+		return zero, fmt.Errorf("[DI Get Failed] %w", err)
+	}
+	return getTyped[View](c, viewType, instance)
+}
+
+// ResolveNamedGroup Name-prefixed group resolution on the global container, see ContainerResolveNamedGroup
+func ResolveNamedGroup[T any](prefix string) (map[string]T, error) {
+	return ContainerResolveNamedGroup[T](Global, prefix)
+}
+
+// ContainerResolveNamedGroup Resolves every named service whose name starts with prefix into a map keyed by the name with
+// the prefix stripped (e.g. "db.primary", "db.replica1" with prefix "db." yield "primary", "replica1"). A name matches when
+// it has a registration of exactly T, or, for interface T, one implementing T (the earliest registered if several do)
+func ContainerResolveNamedGroup[T any](c *Container, prefix string) (map[string]T, error) {
+	svcType := reflect.TypeOf((*T)(nil)).Elem()
+
+	c.mu.RLock()
+	defs := make(map[string]*ServiceDef)
+	for name, namedMap := range c.namedServices {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if def, exists := namedMap[svcType]; exists {
+			defs[name] = def
+			continue
+		}
+		if svcType.Kind() != reflect.Interface {
+			continue
+		}
+		for t, def := range namedMap {
+			if implementsInterface(t, svcType) && (defs[name] == nil || def.order < defs[name].order) {
+				defs[name] = def
+			}
+		}
+	}
+	c.mu.RUnlock()
+
+	group := make(map[string]T, len(defs))
+	for name, def := range defs {
+		instance, err := c.resolveNamedDef(def)
+		if err != nil {
+			return nil, fmt.Errorf("[DI Get Failed] name: %s, %w", name, err)
+		}
+		typed, err := getTyped[T](c, svcType, instance)
+		if err != nil {
+			return nil, fmt.Errorf("name: %s, %w", name, err)
+		}
+		group[strings.TrimPrefix(name, prefix)] = typed
+	}
+	return group, nil
+}
+
+// RegisterGroup Adds a constructor to the value group named group. Members keep their concrete types and need not share
+// one: ResolveGroup/GetGroup convert each to the requested element type (e.g. every router of "routes" to http.Handler)
+func (c *Container) RegisterGroup(group string, ctor any, scope LifetimeScope, opts ...RegisterOption) error {
+	if group == "" {
+		return fmt.Errorf("group cannot be empty for group registration")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, serviceDef, err := newCtorServiceDef(ctor, nil, scope)
+	if err != nil {
+		return err
+	}
+	applyRegisterOptions(serviceDef, opts)
+	serviceDef.order = c.nextOrder()
+	c.groups[group] = append(c.groups[group], serviceDef)
+	return nil
+}
+
+// RegisterInstanceGroup Instance version of RegisterGroup (Transient not supported)
+func (c *Container) RegisterInstanceGroup(group string, instance any, scope LifetimeScope, opts ...RegisterOption) error {
+	if group == "" {
+		return fmt.Errorf("group cannot be empty for group registration")
+	}
+	if scope == Transient {
+		return ErrTransientInstance
+	}
+	if isNilInstance(instance) {
+		return ErrNilInstance
+	}
+	instVal := reflect.ValueOf(instance)
+	serviceDef := &ServiceDef{
+		implType:   instVal.Type(),
+		scope:      scope,
+		instance:   addressable(instVal),
+		isInstance: true,
+	}
+	applyRegisterOptions(serviceDef, opts)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	serviceDef.order = c.nextOrder()
+	c.groups[group] = append(c.groups[group], serviceDef)
+	return nil
+}
+
+// ResolveGroup Resolves every member of group into out (a pointer to a slice), sorted ascending by priority then
+// registration order, converting each member to the slice element type (e.g. concrete routers into []http.Handler).
+// Scoped constructors are skipped on the root container; an unknown group yields an empty slice
+func (c *Container) ResolveGroup(group string, out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	sliceType := outVal.Elem().Type()
+	if sliceType.Kind() != reflect.Slice {
+		return fmt.Errorf("ResolveGroup output parameter must be a slice pointer, current type: %s", sliceType)
+	}
+	values, err := c.groupValues(group, sliceType.Elem())
+	if err != nil {
+		return err
+	}
+	results := reflect.MakeSlice(sliceType, 0, len(values))
+	outVal.Elem().Set(reflect.Append(results, values...))
+	return nil
+}
+
+// GetGroup Group resolution on the global container, see ContainerGetGroup
+func GetGroup[T any](group string) ([]T, error) {
+	return ContainerGetGroup[T](Global, group)
+}
+
+// ContainerGetGroup Generic version of Container.ResolveGroup: every member of group converted to T
+func ContainerGetGroup[T any](c *Container, group string) ([]T, error) {
+	itemType := reflect.TypeOf((*T)(nil)).Elem()
+	values, err := c.groupValues(group, nil)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]T, 0, len(values))
+	for _, value := range values {
+		typed, err := getTyped[T](c, itemType, value)
+		if err != nil {
+			return nil, fmt.Errorf("group: %s, %w", group, err)
+		}
+		members = append(members, typed)
+	}
+	return members, nil
+}
+
+// groupValues Resolves the members of group in priority/registration order, converted to itemType unless it is nil
+func (c *Container) groupValues(group string, itemType reflect.Type) ([]reflect.Value, error) {
+	c.mu.RLock()
+	defs := append([]*ServiceDef(nil), c.groups[group]...)
+	c.mu.RUnlock()
+	sort.SliceStable(defs, func(i, j int) bool {
+		if defs[i].priority != defs[j].priority {
+			return defs[i].priority < defs[j].priority
+		}
+		return defs[i].order < defs[j].order
+	})
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	values := make([]reflect.Value, 0, len(defs))
+	for _, def := range defs {
+		if scopedOnly(def) {
+			continue
+		}
+		instance, err := c.resolveDef(def, track)
+		if err != nil {
+			return nil, fmt.Errorf("[DI Get Failed] group: %s, member %s: %w", group, def.implType, err)
+		}
+		if itemType != nil {
+			if instance, err = convertInstance(itemType, instance, c.isStrict()); err != nil {
+				return nil, fmt.Errorf("group: %s, %w", group, err)
+			}
+		}
+		values = append(values, instance)
+	}
+	return values, nil
+}
+
+// RegisterWithInit Method injection registration: after construction, the named method is called on the instance
+// with its parameters resolved from the container (two-phase init for types that can't take all deps in the constructor)
+func (c *Container) RegisterWithInit(ctor any, initMethodName string, scope LifetimeScope) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctorType := reflect.TypeOf(ctor)
+	if ctorType == nil || ctorType.Kind() != reflect.Func {
+		return ErrNotFunc
+	}
+	if ctorType.NumOut() != 1 {
+		return fmt.Errorf("%w, current return value count: %d", ErrNoReturn, ctorType.NumOut())
+	}
+	implType := ctorType.Out(0)
+	if implType.Kind() == reflect.Interface {
+		return fmt.Errorf("%w, return value is interface: %s", ErrNotConcreteType, implType)
+	}
+	method, err := lookupInitMethod(implType, initMethodName)
+	if err != nil {
+		return err
+	}
+
+	if err := c.register(ctor, nil, scope); err != nil {
+		return err
+	}
+	c.services[implType].initMethod = &method
+	return nil
+}
+
+// lookupInitMethod Locates and validates an init method: must exist, must not be variadic, may only return nothing or error
+func lookupInitMethod(implType reflect.Type, name string) (reflect.Method, error) {
+	method, ok := implType.MethodByName(name)
+	if !ok {
+		return reflect.Method{}, fmt.Errorf("%w, type %s has no exported method %s", ErrInvalidInitMethod, implType, name)
+	}
+	if method.Type.IsVariadic() {
+		return reflect.Method{}, fmt.Errorf("%w, method %s.%s must not be variadic", ErrInvalidInitMethod, implType, name)
+	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	switch method.Type.NumOut() {
+	case 0:
+	case 1:
+		if method.Type.Out(0) != errorType {
+			return reflect.Method{}, fmt.Errorf("%w, method %s.%s may only return error", ErrInvalidInitMethod, implType, name)
+		}
+	default:
+		return reflect.Method{}, fmt.Errorf("%w, method %s.%s may only return error", ErrInvalidInitMethod, implType, name)
+	}
+	return method, nil
+}
+
+// construct Creates a new instance (no caching): calls the factory if registered via RegisterFactory,
+// otherwise resolves constructor parameters, calls the constructor and runs the optional init method
+func (d *ServiceDef) construct(root *Container, resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error), track *resolveTrack) (_ reflect.Value, err error) {
+	// A panicking constructor/factory/init method becomes a regular error naming the type being built
+	defer recoverConstruction(d.implType, &err)
+
+	// Abort before building anything for an already canceled/expired context
+	if err := track.ctxErr(); err != nil {
+		return reflect.Value{}, fmt.Errorf("%w, resolution of %s aborted: %w", ErrResolveCanceled, d.implType, err)
+	}
+
+	if d.factory != nil {
+		instance, err := d.factory(root)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w, factory for %s failed: %w", ErrCreateInstanceFailed, d.implType, err)
+		}
+		return instance, nil
+	}
+
+	// Recursively resolve all dependency parameters
+	params, err := d.resolveArgs(root, d.params(), resolveParams, track)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Dependencies may have taken long enough for the context to end: check again right before the call
+	if err := track.ctxErr(); err != nil {
+		return reflect.Value{}, fmt.Errorf("%w, resolution of %s aborted: %w", ErrResolveCanceled, d.implType, err)
+	}
+
+	// Call constructor to create instance
+	instance, err := d.invoke(params, track)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Method injection: run configured init method before caching
+	if err := callInitMethod(root, d, instance, resolveParams, track); err != nil {
+		return reflect.Value{}, err
+	}
+	return instance, nil
+}
+
+// invoke Calls the constructor and returns its instance: the error of a fallible constructor fails the call, after the
+// retries of its retry policy (RegisterWithRetry) whose backoff ends early when the resolution context is done
+func (d *ServiceDef) invoke(params []reflect.Value, track *resolveTrack) (reflect.Value, error) {
+	attempts := 1
+	if d.retry != nil {
+		attempts = d.retry.attempts
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		results := d.call(params)
+		if !d.fallible || results[1].IsNil() {
+			return results[0], nil
+		}
+		err = results[1].Interface().(error)
+		if attempt < attempts && d.retry.backoff > 0 {
+			if ctxErr := track.sleep(d.retry.backoff); ctxErr != nil {
+				return reflect.Value{}, fmt.Errorf("%w, resolution of %s aborted after %d attempts: %w", ErrResolveCanceled, d.implType, attempt, ctxErr)
+			}
+		}
+	}
+	if d.retry != nil {
+		err = fmt.Errorf("gave up after %d attempts: %w", attempts, err)
+	}
+	return reflect.Value{}, fmt.Errorf("%w, constructor of %s failed: %w", ErrCreateInstanceFailed, d.implType, err)
+}
+
+// call Calls the constructor with resolved params; a variadic constructor receives its resolved slice as the variadic argument
+func (d *ServiceDef) call(params []reflect.Value) []reflect.Value {
+	if d.ctorType.IsVariadic() {
+		return d.ctor.CallSlice(params)
+	}
+	return d.ctor.Call(params)
+}
+
+// params Constructor parameter types (core optimization: parsed only once, then cached); callers must not modify the result
+func (d *ServiceDef) params() []reflect.Type {
+	d.paramOnce.Do(func() {
+		numIn := d.ctorType.NumIn()
+		params := make([]reflect.Type, numIn)
+		for i := 0; i < numIn; i++ {
+			params[i] = d.ctorType.In(i)
+		}
+		d.paramTypes = params
+	})
+	return d.paramTypes
+}
+
+// ParamTypes Returns a copy of the declared parameter types of the constructor registered (by default) under t, without
+// resolving anything. Instance and factory registrations have no constructor and return an error
+func (c *Container) ParamTypes(t reflect.Type) ([]reflect.Type, error) {
+	c.mu.RLock()
+	serviceDef, exists := c.services[t]
+	c.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, t)
+	}
+	if serviceDef.ctorType == nil {
+		return nil, fmt.Errorf("%w, %s is registered as an instance or factory", ErrNotFunc, t)
+	}
+	return append([]reflect.Type(nil), serviceDef.params()...), nil
+}
+
+// recoverConstruction Deferred by construct: converts a panic into an error wrapping ErrCreateInstanceFailed
+func recoverConstruction(implType reflect.Type, err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w, construction of %s panicked: %v", ErrCreateInstanceFailed, implType, r)
+	}
+}
+
+// resolveArgs Resolves call arguments in order; special parameters are filled instead of resolved:
+// ServiceName receives the registration name, ResolutionInfo the metadata of this resolution, context.Context receives the context passed to ResolveContext
+// (falling back to a registered context.Context, otherwise ErrContextRequired), Resolver receives a view bound to the
+// ongoing resolution (unless Resolver itself is registered), In structs are built field by field
+func (d *ServiceDef) resolveArgs(root *Container, paramTypes []reflect.Type, resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error), track *resolveTrack) ([]reflect.Value, error) {
+	resolveTypes := make([]reflect.Type, 0, len(paramTypes))
+	special := make(map[int]reflect.Value)
+	for i, pType := range paramTypes {
+		switch {
+		case pType == serviceNameType:
+			special[i] = reflect.ValueOf(ServiceName(d.name))
+		case pType == resolutionInfoType:
+			special[i] = reflect.ValueOf(track.info)
+		case pType == contextType && track.ctx != nil:
+			special[i] = reflect.ValueOf(&track.ctx).Elem()
+		case pType == contextType && !root.isRegistered(contextType):
+			return nil, fmt.Errorf("%w, constructor of %s requires context.Context", ErrContextRequired, d.implType)
+		case pType == resolverType && !root.isRegistered(resolverType):
+			special[i] = track.inflightResolver(root, resolveParams)
+		case isInStruct(pType):
+			value, err := resolveInStruct(root, pType, resolveParams, track)
+			if err != nil {
+				return nil, err
+			}
+			special[i] = value
+		default:
+			resolveTypes = append(resolveTypes, pType)
+		}
+	}
+	if len(special) == 0 {
+		return resolveParams(paramTypes, track)
+	}
+
+	resolved, err := resolveParams(resolveTypes, track)
+	if err != nil {
+		return nil, err
+	}
+	params := make([]reflect.Value, len(paramTypes))
+	for i, j := 0, 0; i < len(paramTypes); i++ {
+		if value, ok := special[i]; ok {
+			params[i] = value
+			continue
+		}
+		params[i] = resolved[j]
+		j++
+	}
+	return params, nil
+}
+
+// callInitMethod Resolves the init method's parameters (excluding the receiver) and invokes it on the instance
+func callInitMethod(root *Container, serviceDef *ServiceDef, instance reflect.Value, resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error), track *resolveTrack) error {
+	method := serviceDef.initMethod
+	if method == nil {
+		return nil
+	}
+	paramTypes := make([]reflect.Type, method.Type.NumIn()-1)
+	for i := range paramTypes {
+		paramTypes[i] = method.Type.In(i + 1)
+	}
+	params, err := serviceDef.resolveArgs(root, paramTypes, resolveParams, track)
+	if err != nil {
+		return err
+	}
+	results := method.Func.Call(append([]reflect.Value{instance}, params...))
+	if len(results) == 1 && !results[0].IsNil() {
+		return fmt.Errorf("%w, init method %s failed: %w", ErrCreateInstanceFailed, method.Name, results[0].Interface().(error))
+	}
+	return nil
+}
+
+// RegisterFactory Factory registration on the global container, see ContainerRegisterFactory
+func RegisterFactory[T any](factory func(c *Container) (T, error), scope LifetimeScope) error {
+	return ContainerRegisterFactory[T](Global, factory, scope)
+}
+
+// ContainerRegisterFactory Factory registration: registers T with a closure that receives the container and can do arbitrary lookups
+// (conditional or data-driven construction). Errors propagate through resolution wrapped in ErrCreateInstanceFailed; lifetimes apply to the result.
+func ContainerRegisterFactory[T any](c *Container, factory func(c *Container) (T, error), scope LifetimeScope) error {
+	if factory == nil {
+		return ErrNotFunc
+	}
+	svcType := reflect.TypeOf((*T)(nil)).Elem()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, exists := c.services[svcType]; exists {
+		return duplicateError(svcType, existing, svcType)
+	}
+	c.services[svcType] = &ServiceDef{
+		implType: svcType,
+		scope:    scope,
+		order:    c.nextOrder(),
+		factory: func(c *Container) (reflect.Value, error) {
+			instance, err := factory(c)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			// Take the address so interface-typed T keeps its static type
+			return reflect.ValueOf(&instance).Elem(), nil
+		},
+	}
+	return nil
+}
+
+// Provider Type-safe custom construction of T without a reflective constructor (see RegisterProvider)
+type Provider[T any] interface {
+	Provide(c *Container) (T, error)
+}
+
+// RegisterProvider Provider registration on the global container, see ContainerRegisterProvider
+func RegisterProvider[T any](p Provider[T], scope LifetimeScope) error {
+	return ContainerRegisterProvider[T](Global, p, scope)
+}
+
+// ContainerRegisterProvider Registers T as built by p.Provide, called on resolution like a factory: errors are wrapped in
+// ErrCreateInstanceFailed and the lifetime applies to the provided result (a Singleton is provided once)
+func ContainerRegisterProvider[T any](c *Container, p Provider[T], scope LifetimeScope) error {
+	if p == nil {
+		return fmt.Errorf("%w, provider must not be nil", ErrNotFunc)
+	}
+	return ContainerRegisterFactory[T](c, p.Provide, scope)
+}
+
+// RegisterComputed Computed singleton registration on the global container, see ContainerRegisterComputed
+func RegisterComputed[T any](fn func(c *Container) T) error {
+	return ContainerRegisterComputed[T](Global, fn)
+}
+
+// ContainerRegisterComputed Registers T as a Singleton derived from other services: fn runs lazily on first access and
+// may call Get/Resolve on the container; its result is cached like any singleton (concurrent first resolutions all
+// receive the first cached result) and recomputed after Invalidate/ClearCaches. Unlike RegisterFactory there is no
+// error result and no lifetime choice
+func ContainerRegisterComputed[T any](c *Container, fn func(c *Container) T) error {
+	if fn == nil {
+		return ErrNotFunc
+	}
+	return ContainerRegisterFactory[T](c, func(c *Container) (T, error) {
+		return fn(c), nil
+	}, Singleton)
+}
+
+// dependencyTypes Constructor/init method parameter types that are resolved from the container (empty for instances and factories);
+// a registration derived from an internal one reports the dependencies of that one instead
+func (d *ServiceDef) dependencyTypes() []reflect.Type {
+	if d.via != nil {
+		return d.via.dependencyTypes()
+	}
+	deps := make([]reflect.Type, 0)
+	if d.ctorType != nil {
+		for _, pType := range d.params() {
+			switch {
+			case pType == serviceNameType, pType == resolutionInfoType, pType == resolverType:
+			case isInStruct(pType):
+				deps = append(deps, inDependencies(pType)...)
+			default:
+				deps = append(deps, pType)
+			}
+		}
+	}
+	if d.initMethod != nil {
+		for i := 1; i < d.initMethod.Type.NumIn(); i++ {
+			deps = append(deps, d.initMethod.Type.In(i))
+		}
+	}
+	return deps
+}
+
+// DanglingServices Static check: returns default constructor-based services (in registration order) with at least one
+// parameter that can never be resolved (not registered, not auto-collected slice/map, no pointer/value counterpart).
+// Instances and factories have no declared dependencies and are excluded.
+func (c *Container) DanglingServices() []reflect.Type {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dangling := make([]reflect.Type, 0)
+	for svcType, serviceDef := range c.services {
+		if serviceDef.isInstance || serviceDef.internal {
+			continue
+		}
+		for _, pType := range serviceDef.dependencyTypes() {
+			if !c.isSatisfiableLocked(pType) {
+				dangling = append(dangling, svcType)
+				break
+			}
+		}
+	}
+	sort.Slice(dangling, func(i, j int) bool {
+		return c.services[dangling[i]].order < c.services[dangling[j]].order
+	})
+	return dangling
+}
+
+// isSatisfiableLocked Whether a parameter type can be provided without attempting resolution (caller holds c.mu)
+func (c *Container) isSatisfiableLocked(pType reflect.Type) bool {
+	// The default registration or a single (or primary) additional implementation resolves, several are ambiguous
+	if def, _ := c.registrationLocked(pType); def != nil {
+		return true
+	}
+	// context.Context may be supplied at resolution time (ResolveContext), Resolver is injected by the resolver itself
+	if pType == contextType || pType == resolverType {
+		return true
+	}
+	// Unregistered slices and map[string]T are auto-collected (possibly empty)
+	if pType.Kind() == reflect.Slice || (pType.Kind() == reflect.Map && pType.Key().Kind() == reflect.String) {
+		return true
+	}
+	_, ok := c.pointerCounterpartLocked(pType)
+	return ok
+}
+
+// EnableStats Enables/disables counting resolutions per registration (counting starts when enabled), the basis of
+// UnusedRegistrations. Off by default to keep resolution free of bookkeeping
+func (c *Container) EnableStats(enabled bool) {
+	c.stats.Store(enabled)
+}
+
+// recordResolve Counts a resolution of serviceDef when stats are enabled
+func (c *Container) recordResolve(serviceDef *ServiceDef) {
+	if c.stats.Load() {
+		serviceDef.resolves.Add(1)
+	}
+}
+
+// UnusedRegistrations Default registrations (in registration order) that were never resolved, directly or as a
+// dependency, since stats were enabled: candidates for dead wiring. Returns nil when stats are disabled
+func (c *Container) UnusedRegistrations() []reflect.Type {
+	if !c.stats.Load() {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	unused := make([]reflect.Type, 0)
+	for svcType, serviceDef := range c.services {
+		if serviceDef.resolves.Load() == 0 && !serviceDef.internal {
+			unused = append(unused, svcType)
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		return c.services[unused[i]].order < c.services[unused[j]].order
+	})
+	return unused
+}
+
+// Validate Static check of the registrations: reports captive dependencies, i.e. Singleton services whose constructor
+// depends (directly or through Transient services) on a Scoped service, which would capture the instance of the first
+// resolving scope for the container's lifetime. Instances have no constructor dependencies and are never captive.
+// Every offending service is reported (registration order), aggregated with errors.Join
+func (c *Container) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type entry struct {
+		label string
+		def   *ServiceDef
+	}
+	entries := make([]entry, 0)
+	add := func(label string, def *ServiceDef) {
+		if def.scope == Singleton && !def.isInstance && !def.internal {
+			entries = append(entries, entry{label, def})
+		}
+	}
+	for svcType, def := range c.services {
+		add(svcType.String(), def)
+	}
+	for name, namedMap := range c.namedServices {
+		for svcType, def := range namedMap {
+			add(fmt.Sprintf("%s [%s]", svcType, name), def)
+		}
+	}
+	for id, def := range c.keyedServices {
+		add(fmt.Sprintf("%s [key %s]", def.implType, id.(*keyID).name), def)
+	}
+	for _, impls := range c.implementations {
+		for _, def := range impls {
+			add(def.implType.String(), def)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].def.order < entries[j].def.order })
+
+	var errs []error
+	for _, e := range entries {
+		if chain := c.scopedDependencyLocked(e.def, map[*ServiceDef]bool{e.def: true}); chain != nil {
+			path := make([]string, len(chain))
+			for i, t := range chain {
+				path[i] = t.String()
+			}
+			errs = append(errs, fmt.Errorf("%w: Singleton %s -> %s (Scoped)", ErrCaptiveDependency, e.label, strings.Join(path, " -> ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// scopedDependencyLocked Dependency chain from def to the first Scoped registration it reaches directly or through
+// Transient registrations, nil if none (caller holds c.mu)
+func (c *Container) scopedDependencyLocked(def *ServiceDef, visiting map[*ServiceDef]bool) []reflect.Type {
+	for _, pType := range def.dependencyTypes() {
+		dep, _ := c.registrationLocked(pType)
+		// Scoped instances are one shared value, capturing them is harmless
+		if dep == nil || dep.isInstance {
+			continue
+		}
+		if dep.scope == Scoped {
+			return []reflect.Type{pType}
+		}
+		if dep.scope == Transient && !visiting[dep] {
+			visiting[dep] = true
+			if chain := c.scopedDependencyLocked(dep, visiting); chain != nil {
+				return append([]reflect.Type{pType}, chain...)
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterInstance Instance registration: directly registers a created instance, registers by instance type
+// Note: Does not support Transient lifetime (instance already created, cannot return new instance each time)
+func (c *Container) RegisterInstance(instance any, scope LifetimeScope) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerInstance(instance, nil, scope)
+}
+
+// RegisterInstanceWithCleanup Instance registration with a cleanup callback for instances that need explicit release
+// but do not implement io.Closer. The instance is shared by every scope, so its cleanup runs once on Container.Close
+// whatever its lifetime (never on Scope.Dispose). Cleanups run in reverse registration order
+func (c *Container) RegisterInstanceWithCleanup(instance any, scope LifetimeScope, cleanup func()) error {
+	if cleanup == nil {
+		return fmt.Errorf("cleanup cannot be nil")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.registerInstance(instance, nil, scope); err != nil {
+		return err
+	}
+	c.services[reflect.TypeOf(instance)].cleanup = cleanup
+	return nil
+}
+
+// RegisterInstanceAs Instance interface registration: registers a created instance as specified interface type
+// Note: Does not support Transient lifetime (instance already created, cannot return new instance each time)
+func (c *Container) RegisterInstanceAs(instance any, interfaceType any, scope LifetimeScope) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerInstance(instance, interfaceType, scope)
+}
+
+// RegisterInstanceAs Instance interface registration on the global container with the interface as type parameter,
+// see ContainerRegisterInstanceAs
+func RegisterInstanceAs[Iface any](instance any, scope LifetimeScope) error {
+	return ContainerRegisterInstanceAs[Iface](Global, instance, scope)
+}
+
+// ContainerRegisterInstanceAs Registers a created instance as interface Iface, a readable alternative to passing
+// (*Iface)(nil) to Container.RegisterInstanceAs. The instance must implement Iface
+func ContainerRegisterInstanceAs[Iface any](c *Container, instance any, scope LifetimeScope) error {
+	if reflect.TypeOf((*Iface)(nil)).Elem().Kind() != reflect.Interface {
+		return ErrInvalidInterfaceType
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerInstance(instance, (*Iface)(nil), scope)
+}
+
+// registerInstance Internal instance registration logic
+func (c *Container) registerInstance(instance any, interfaceType any, scope LifetimeScope) error {
+	// Transient does not support instance registration (cannot create new instance each time)
+	if scope == Transient {
+		return ErrTransientInstance
+	}
+
+	// Validate instance is not nil
+	if isNilInstance(instance) {
+		return ErrNilInstance
+	}
+
+	instVal := reflect.ValueOf(instance)
+	implType := instVal.Type()
+
+	// Determine final registered service type (interface/implementation type)
+	svcType, err := instanceServiceType(implType, interfaceType)
+	if err != nil {
+		return err
+	}
+	instVal = conformInstance(instVal, svcType)
+	implType = instVal.Type()
+
+	// Check for duplicate registration
+	if existing, exists := c.services[svcType]; exists {
+		return duplicateError(svcType, existing, implType)
+	}
+
+	// Encapsulate service definition and add to container
+	c.services[svcType] = &ServiceDef{
+		implType:   implType,
+		scope:      scope,
+		instance:   addressable(instVal),
+		isInstance: true,
+		order:      c.nextOrder(),
+	}
+	return nil
+}
+
+// instanceServiceType Determines the service type an instance is registered under (instance type, or the target
+// interface/concrete type given as a nil pointer), validating assignability
+func instanceServiceType(implType reflect.Type, interfaceType any) (reflect.Type, error) {
+	svcType := implType
+	if interfaceType != nil {
+		// Parse target type
+		targetType := reflect.TypeOf(interfaceType)
+
+		// Check if it's a pointer type
+		if targetType.Kind() != reflect.Ptr {
+			return nil, ErrInvalidInterfaceType
+		}
+
+		// Get the element type pointed to by the pointer
+		elemType := targetType.Elem()
+
+		// Determine if it points to an interface or concrete type
+		if elemType.Kind() == reflect.Interface {
+			// Interface type: use interface type as service type
+			svcType = elemType
+			if !implType.Implements(svcType) {
+				return nil, fmt.Errorf("%w, instance type %s does not implement interface %s", ErrInstanceNotAssignable, implType, svcType)
+			}
+		} else {
+			// Concrete type: use complete pointer type as service type
+			// Example: (*UserService)(nil) -> register as *UserService type
+			svcType = targetType
+			// Enhanced type compatibility check, supports pointer/value type conversion
+			if !isTypeCompatible(implType, svcType) {
+				return nil, fmt.Errorf("%w, instance type %s cannot be converted to target type %s", ErrInstanceNotAssignable, implType, svcType)
+			}
+		}
+	}
+	return svcType, nil
+}
+
+// conformInstance Converts an instance registered under a convertible but distinct defined type (e.g. *Settings registered
+// as *map[string]string) to the registered type, so resolving by that type always yields exactly that type.
+// Type aliases need no conversion: an alias and its target are the same reflect.Type
+func conformInstance(instVal reflect.Value, svcType reflect.Type) reflect.Value {
+	if svcType.Kind() != reflect.Interface && !instVal.Type().AssignableTo(svcType) && instVal.Type().ConvertibleTo(svcType) {
+		return instVal.Convert(svcType)
+	}
+	return instVal
+}
+
+// RegisterInstanceNamed Named instance registration: registers an instance with a name, allows multiple instances of the same type
+func (c *Container) RegisterInstanceNamed(name string, instance any, scope LifetimeScope, opts ...RegisterOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerInstanceNamed(name, instance, nil, scope, opts)
+}
+
+// RegisterInstanceAsNamed Named instance interface registration: registers an instance with a name as specified type
+func (c *Container) RegisterInstanceAsNamed(name string, instance any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerInstanceNamed(name, instance, interfaceType, scope, opts)
+}
+
+// RegisterInstanceNamed Typed named instance registration on the global container, see ContainerRegisterInstanceNamed
+func RegisterInstanceNamed[T any](name string, instance T, scope LifetimeScope, opts ...RegisterOption) error {
+	return ContainerRegisterInstanceNamed(Global, name, instance, scope, opts...)
+}
+
+// ContainerRegisterInstanceNamed Named instance registration keyed by the static type T instead of the instance's
+// dynamic type: an interface value passed as T (e.g. an ICache holding *RedisCache) is registered as ICache
+func ContainerRegisterInstanceNamed[T any](c *Container, name string, instance T, scope LifetimeScope, opts ...RegisterOption) error {
+	var interfaceType any
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Interface {
+		interfaceType = (*T)(nil)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerInstanceNamed(name, instance, interfaceType, scope, opts)
+}
+
+// registerInstanceNamed Internal named instance registration logic
+func (c *Container) registerInstanceNamed(name string, instance any, interfaceType any, scope LifetimeScope, opts []RegisterOption) error {
+	// Transient does not support instance registration
+	if scope == Transient {
+		return ErrTransientInstance
+	}
+
+	// Validate instance is not nil
+	if isNilInstance(instance) {
+		return ErrNilInstance
+	}
+
+	// Validate name is not empty
+	if name == "" {
+		return fmt.Errorf("name cannot be empty for named registration")
+	}
+
+	instVal := reflect.ValueOf(instance)
+	implType := instVal.Type()
+
+	// Determine final registered service type
+	svcType, err := instanceServiceType(implType, interfaceType)
+	if err != nil {
+		return err
+	}
+	instVal = conformInstance(instVal, svcType)
+	implType = instVal.Type()
+	registeredName := name
+
+	// Initialize named services map (under the registered spelling of a matching name)
+	name = c.namedKeyLocked(name)
+	if c.namedServices[name] == nil {
+		c.namedServices[name] = make(map[reflect.Type]*ServiceDef)
+	}
+
+	// Check for duplicate registration
+	if _, exists := c.namedServices[name][svcType]; exists {
+		return fmt.Errorf("%w, name: %s, type: %s", ErrRegisterDuplicate, name, svcType)
+	}
+
+	// Encapsulate service definition and add to container
+	serviceDef := &ServiceDef{
+		implType:   implType,
+		scope:      scope,
+		instance:   addressable(instVal),
+		isInstance: true,
+		name:       registeredName,
+		order:      c.nextOrder(),
+	}
+	applyRegisterOptions(serviceDef, opts)
+	c.namedServices[name][svcType] = serviceDef
+	return nil
+}
+
+// isTypeCompatible Checks if two types are compatible (supports pointer/value type conversion)
+func isTypeCompatible(implType, targetType reflect.Type) bool {
+	// Directly assignable (including same type)
+	if implType.AssignableTo(targetType) {
+		return true
+	}
+
+	// Convertible
+	if implType.ConvertibleTo(targetType) {
+		return true
+	}
+
+	// Check pointer type compatibility: if implementation is value type, target is corresponding pointer type
+	if implType.Kind() != reflect.Ptr && reflect.PointerTo(implType).AssignableTo(targetType) {
+		return true
+	}
+
+	// Check reverse pointer type compatibility: if implementation is pointer type, target is corresponding value type
+	if implType.Kind() == reflect.Ptr && implType.Elem().AssignableTo(targetType) {
+		return true
+	}
+
+	return false
+}
+
+// SetNilEmptySlices Controls auto-collected slices (slice parameters and Get[[]Iface]) when no service matches:
+// false (default) yields an empty non-nil slice, true yields nil. Auto-collected maps are always non-nil
+func (c *Container) SetNilEmptySlices(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nilEmptySlices = enabled
+}
+
+// RequireImplementations Enables/disables failing auto-collection of a slice of interfaces (slice parameters and
+// Get[[]Iface]) that nothing implements with ErrNoImplementation, instead of yielding an empty slice. Default off
+func (c *Container) RequireImplementations(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requireImpls = enabled
+}
+
+// MergeRegisteredSlices Enables/disables merging for slice parameters whose slice type is registered: the registered
+// slice comes first in its own order, followed by the auto-collected elements (default registration, named instances,
+// RegisterImplementation) it does not already contain, compared by instance identity. The registered slice itself is
+// never modified. Default off (the registered slice is injected as is)
+func (c *Container) MergeRegisteredSlices(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mergeSlices = enabled
+}
+
+// DefaultMapKey Conventional key for the default (unnamed) registration in auto-collected maps, see SetMapDefaultKey
+const DefaultMapKey = "default"
+
+// SetMapDefaultKey Enables/disables including the default (unnamed) registration of T in auto-collected map[string]T
+// parameters under key (e.g. DefaultMapKey or ""), next to the named registrations. A named registration using the same
+// name takes precedence. Default off: auto-collected maps only contain named registrations
+func (c *Container) SetMapDefaultKey(key string, enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mapDefault = enabled
+	c.mapDefaultKey = key
+}
+
+// SetNameMatcher Sets how service names are compared (e.g. strings.EqualFold for case-insensitive names from config), nil
+// restores exact matching. ResolveNamed and named registration use it: registering "foo" after "Foo" joins the "Foo"
+// entry, so the duplicate check applies to both spellings
+func (c *Container) SetNameMatcher(matcher func(a, b string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nameMatcher = matcher
+}
+
+// namedKeyLocked Returns the registered name that name refers to under the name matcher, or name itself if none matches
+// (the smallest one if several registered names match); caller holds c.mu
+func (c *Container) namedKeyLocked(name string) string {
+	if c.nameMatcher == nil {
+		return name
+	}
+	if _, exists := c.namedServices[name]; exists {
+		return name
+	}
+	key, found := name, false
+	for registered := range c.namedServices {
+		if c.nameMatcher(name, registered) && (!found || registered < key) {
+			key, found = registered, true
+		}
+	}
+	return key
+}
+
+// mergesSlices Reports whether MergeRegisteredSlices is enabled
+func (c *Container) mergesSlices() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mergeSlices
+}
+
+// emptySlice Applies the SetNilEmptySlices and RequireImplementations settings to an auto-collected slice
+func (c *Container) emptySlice(results reflect.Value) (reflect.Value, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if results.Len() > 0 {
+		return results, nil
+	}
+	if elemType := results.Type().Elem(); c.requireImpls && elemType.Kind() == reflect.Interface {
+		return reflect.Value{}, fmt.Errorf("%w, collecting %s", ErrNoImplementation, results.Type())
+	}
+	if c.nilEmptySlices {
+		return reflect.Zero(results.Type()), nil
+	}
+	return results, nil
+}
+
+// ResolveTransform Replaces a value resolved by type before it is handed out (e.g. wraps it in a tracing proxy); the
+// result must be assignable to t
+type ResolveTransform func(t reflect.Type, v reflect.Value) reflect.Value
+
+// resolveTransform A registered ResolveTransform and whether it also applies to cached instances
+type resolveTransform struct {
+	fn        ResolveTransform
+	cacheHits bool
+}
+
+// OnResolveTransform Adds a hook applied to every value resolved by type (Resolve, Get, constructor dependencies, in
+// root and scopes) before it is handed out. With cacheHits false it only sees freshly constructed values; with true it
+// also sees cached singletons/Scoped values and pre-registered instances. Transforms run in registration order, each
+// receiving the previous result; the transformed value is handed out but never cached
+func (c *Container) OnResolveTransform(fn ResolveTransform, cacheHits bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transforms = append(c.transforms, resolveTransform{fn: fn, cacheHits: cacheHits})
+}
+
+// finishResolve Final step of a resolution by type: rejects an interface service whose instance is nil, converts the
+// instance to svcType, then applies the resolve transforms
+func (c *Container) finishResolve(svcType reflect.Type, instance reflect.Value, fromCache bool) (reflect.Value, error) {
+	if svcType.Kind() == reflect.Interface && isNilValue(instance) {
+		return reflect.Value{}, fmt.Errorf("%w, type: %s", ErrNilInterfaceInstance, svcType)
+	}
+	// A registration only convertible to svcType (e.g. *StrictInt registered as *int64) is converted here, or rejected
+	// under StrictTypes, so every caller receives a value assignable to the requested type
+	if instance.IsValid() && !instance.Type().AssignableTo(svcType) {
+		conv, err := convertInstance(svcType, instance, c.isStrict())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		instance = conv
+	}
+	return c.applyTransforms(svcType, instance, fromCache)
+}
+
+// isNilInstance Whether an instance to register is nil, including a typed nil pointer such as (*T)(nil) wrapped in any
+func isNilInstance(instance any) bool {
+	if instance == nil {
+		return true
+	}
+	v := reflect.ValueOf(instance)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// storedInstance Returns the pre-registered instance of an instance registration, guarding against a stored value that
+// has become a nil pointer (ErrNilInstance) instead of handing it out as a typed nil
+func (d *ServiceDef) storedInstance() (reflect.Value, error) {
+	if d.instance.Kind() == reflect.Ptr && d.instance.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%w, stored instance of %s is a nil pointer", ErrNilInstance, d.implType)
+	}
+	return d.instance, nil
+}
+
+// isNilValue Whether v is invalid or a nil pointer/interface/map/slice/func/chan
+func isNilValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// applyTransforms Runs the OnResolveTransform hooks over a resolved value
+func (c *Container) applyTransforms(svcType reflect.Type, instance reflect.Value, fromCache bool) (reflect.Value, error) {
+	c.mu.RLock()
+	transforms := c.transforms
+	c.mu.RUnlock()
+	for _, t := range transforms {
+		if fromCache && !t.cacheHits {
+			continue
+		}
+		instance = t.fn(svcType, instance)
+		if !instance.IsValid() {
+			return reflect.Value{}, fmt.Errorf("%w, resolve transform for %s returned an invalid value", ErrTypeConvertFailed, svcType)
+		}
+		if !instance.Type().AssignableTo(svcType) {
+			return reflect.Value{}, fmt.Errorf("%w, resolve transform for %s returned %s", ErrTypeConvertFailed, svcType, instance.Type())
+		}
+	}
+	return instance, nil
+}
+
+// FallbackFunc Supplies a value for a type that is not registered; ok=false continues with ErrServiceNotRegistered
+type FallbackFunc func(t reflect.Type) (reflect.Value, bool, error)
+
+// SetFallback Sets a provider consulted when a resolved type is not registered (after implementations and
+// pointer/value counterparts), e.g. to integrate an external registry or config system. The value is neither cached
+// nor lifetime-managed; nil (default) disables the fallback
+func (c *Container) SetFallback(fn FallbackFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallback = fn
+}
+
+// SingletonStore Pluggable storage of default (type-keyed) Singleton instances, e.g. a persistent cache restoring
+// singletons after a warm restart. Implementations must be safe for concurrent use
+type SingletonStore interface {
+	Get(t reflect.Type) (reflect.Value, bool)
+	Set(t reflect.Type, v reflect.Value)
+}
+
+// MemorySingletonStore In-memory SingletonStore backed by a map
+type MemorySingletonStore struct {
+	mu        sync.RWMutex
+	instances map[reflect.Type]reflect.Value
+}
+
+// NewMemorySingletonStore Creates an empty in-memory singleton store
+func NewMemorySingletonStore() *MemorySingletonStore {
+	return &MemorySingletonStore{instances: make(map[reflect.Type]reflect.Value)}
+}
+
+// Get Returns the instance stored for t
+func (m *MemorySingletonStore) Get(t reflect.Type) (reflect.Value, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.instances[t]
+	return v, ok
+}
+
+// Set Stores the instance of t
+func (m *MemorySingletonStore) Set(t reflect.Type, v reflect.Value) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.instances[t] = v
+}
+
+// SetSingletonStore Plugs external storage into the caching of default (type-keyed) Singletons: a singleton not yet
+// cached by the container is first looked up in the store (and adopted without running its constructor or OnFirstInit
+// callbacks), and every newly constructed one is written to it. Named, keyed and implementation registrations are not
+// stored; ClearCaches does not clear the store. nil (default) keeps singletons in the container's memory only
+func (c *Container) SetSingletonStore(store SingletonStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.singletonStore = store
+}
+
+// resolveFallback Consults the fallback provider for an unregistered type, returning ErrServiceNotRegistered when
+// there is none or it declines
+func (c *Container) resolveFallback(svcType reflect.Type) (reflect.Value, error) {
+	c.mu.RLock()
+	fallback := c.fallback
+	c.mu.RUnlock()
+	if fallback != nil {
+		value, ok, err := fallback(svcType)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("fallback for %s failed: %w", svcType, err)
+		}
+		if ok {
+			if !value.IsValid() {
+				return reflect.Value{}, fmt.Errorf("%w, fallback returned an invalid value for %s", ErrTypeConvertFailed, svcType)
+			}
+			if !value.Type().AssignableTo(svcType) {
+				return reflect.Value{}, fmt.Errorf("%w, fallback value for %s has type %s", ErrTypeConvertFailed, svcType, value.Type())
+			}
+			return value, nil
+		}
+	}
+	if svcType.Kind() == reflect.Interface {
+		return reflect.Value{}, c.unregisteredInterfaceError(svcType)
+	}
+	return reflect.Value{}, fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, svcType)
+}
+
+// StrictTypes Enables/disables strict type resolution: when enabled, a resolved instance is only handed out as a type
+// it is assignable to, never through a ConvertibleTo conversion (which may yield a copy rather than the registered
+// value). Default off for backward compatibility
+func (c *Container) StrictTypes(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.strictTypes = enabled
+}
+
+// isStrict Whether strict type resolution is enabled
+func (c *Container) isStrict() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.strictTypes
+}
+
+// AllowPointerCycles Enables/disables two-phase construction of default Singletons whose constructor returns exactly the
+// registered *struct type, so mutually referencing singletons resolve instead of failing with
+// ErrResolveCircularDependency: a zero *T is allocated before the constructor runs and handed to any dependency resolving
+// T again, then the constructed value is copied into it and cached. Hazards: such dependencies observe a zero value until
+// the outer constructor returns (they must only store the pointer, not use it), the copy is shallow (the pointer the
+// constructor returned is discarded, so it must not capture itself, e.g. in closures or goroutines, and must not contain
+// locks in use), and Transient/Scoped or interface registrations still report the cycle. Default off
+func (c *Container) AllowPointerCycles(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pointerCycles = enabled
+}
+
+// beginPartial Pre-allocates the instance of a pointer singleton about to be constructed when AllowPointerCycles is
+// enabled (nil otherwise); the caller must call endPartial once construction has finished
+func (c *Container) beginPartial(svcType reflect.Type, serviceDef *ServiceDef, lifetime LifetimeScope, track *resolveTrack) *partialSingleton {
+	if lifetime != Singleton || serviceDef.implType != svcType || svcType.Kind() != reflect.Ptr || svcType.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	c.mu.RLock()
+	enabled := c.pointerCycles
+	c.mu.RUnlock()
+	if !enabled {
+		return nil
+	}
+	partial := &partialSingleton{ptr: reflect.New(svcType.Elem())}
+	if track.partial == nil {
+		track.partial = make(map[reflect.Type]*partialSingleton)
+	}
+	track.partial[svcType] = partial
+	return partial
+}
+
+// endPartial Finishes two-phase construction (err is the construction error, passed through): if a dependency received
+// the pre-allocated instance, the constructed value is copied into it and the pre-allocated instance is returned instead.
+// On failure the singletons cached meanwhile may hold the never-completed instance, so their caches are dropped
+func (c *Container) endPartial(svcType reflect.Type, partial *partialSingleton, instance reflect.Value, err error, track *resolveTrack) (reflect.Value, error) {
+	if partial == nil {
+		return instance, err
+	}
+	delete(track.partial, svcType)
+	if err == nil && partial.used && instance.IsNil() {
+		err = fmt.Errorf("%w, constructor of %s returned nil while dependencies hold its pre-allocated instance", ErrCreateInstanceFailed, svcType)
+	}
+	if err != nil {
+		if partial.used {
+			c.dropSingletons(partial.dependents)
+		}
+		return reflect.Value{}, err
+	}
+	if !partial.used {
+		return instance, nil
+	}
+	partial.ptr.Elem().Set(instance.Elem())
+	return partial.ptr, nil
+}
+
+// recordSingleton Notes a default singleton cached while pointer singletons are under two-phase construction
+func (t *resolveTrack) recordSingleton(svcType reflect.Type, serviceDef *ServiceDef) {
+	for _, partial := range t.partial {
+		if partial.dependents == nil {
+			partial.dependents = make(map[reflect.Type]*ServiceDef)
+		}
+		partial.dependents[svcType] = serviceDef
+	}
+}
+
+// dropSingletons Swaps in fresh registrations for the given cached default singletons (as Invalidate does), unless
+// they were replaced in the meantime
+func (c *Container) dropSingletons(singletons map[reflect.Type]*ServiceDef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for svcType, serviceDef := range singletons {
+		if c.services[svcType] == serviceDef {
+			c.services[svcType] = serviceDef.cloneRegistration()
+		}
+	}
+}
+
+// cyclePartial Returns the pre-allocated instance of svcType if it is under two-phase construction in this resolution
+func (t *resolveTrack) cyclePartial(svcType reflect.Type) (reflect.Value, bool) {
+	partial, ok := t.partial[svcType]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	partial.used = true
+	return partial.ptr, true
+}
+
+// OnFirstInit Registers a callback fired exactly once when the default (unnamed) Singleton registered under type t is
+// first constructed, with the cached instance (e.g. to register it with a monitor). Callbacks run inside the
+// singleton's sync.Once, so concurrent resolvers wait for them; pre-registered instances are never constructed
+// and do not fire
+func (c *Container) OnFirstInit(t reflect.Type, fn func(any)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.firstInit == nil {
+		c.firstInit = make(map[reflect.Type][]func(any))
+	}
+	c.firstInit[t] = append(c.firstInit[t], fn)
+}
+
+// fireFirstInit Runs the OnFirstInit callbacks registered for svcType (called without holding c.mu)
+func (c *Container) fireFirstInit(svcType reflect.Type, instance reflect.Value) {
+	c.mu.RLock()
+	hooks := c.firstInit[svcType]
+	c.mu.RUnlock()
+	for _, fn := range hooks {
+		fn(instance.Interface())
+	}
+}
+
+// SetAutoDeref Enables/disables automatic pointer/value adaptation: when T (or *T) is not registered,
+// resolution falls back to a registered *T (or T) and dereferences (or takes the address of) it
+func (c *Container) SetAutoDeref(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.autoDeref = enabled
+}
+
+// MatchUnderlyingTypes Enables/disables underlying type matching: when a defined type (type Settings map[string]string)
+// is not registered, resolution falls back to a registration of its underlying type and converts, and vice versa.
+// Only a defined type and its own underlying type match (same kind, one side predeclared or unnamed); two defined
+// types sharing an underlying type never do. Ignored under StrictTypes. Default off
+func (c *Container) MatchUnderlyingTypes(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.underlying = enabled
+}
+
+// unregisteredInterfaceError Explains why an interface is not resolvable: nothing implements it (also ErrNoImplementation),
+// or the registrations implementing it are only registered under their own types
+func (c *Container) unregisteredInterfaceError(svcType reflect.Type) error {
+	c.mu.RLock()
+	implementers := make([]string, 0)
+	for regType := range c.services {
+		if regType.Kind() != reflect.Interface && regType.Implements(svcType) {
+			implementers = append(implementers, regType.String())
+		}
+	}
+	c.mu.RUnlock()
+	if len(implementers) == 0 {
+		return fmt.Errorf("%w, type: %s: %w", ErrServiceNotRegistered, svcType, ErrNoImplementation)
+	}
+	sort.Strings(implementers)
+	return fmt.Errorf("%w, type: %s, implemented by [%s] registered under their own types (use RegisterAs, RegisterImplementation or EnableImplementsScan)",
+		ErrServiceNotRegistered, svcType, strings.Join(implementers, ", "))
+}
+
+// EnableImplementsScan Enables/disables the implements scan: an interface that is not registered (neither directly nor
+// through RegisterImplementation) resolves from the single default registration of a concrete type implementing it, so
+// RegisterAs is not needed for every interface. Several implementing registrations are ambiguous. Scanning is O(n)
+// in the number of registrations, hence off by default
+func (c *Container) EnableImplementsScan(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.implementsScan = enabled
+}
+
+// scanImplementation Finds the single default registration implementing an unregistered interface (EnableImplementsScan)
+func (c *Container) scanImplementation(svcType reflect.Type) (reflect.Type, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.implementsScan || svcType.Kind() != reflect.Interface {
+		return nil, false, nil
+	}
+
+	matches := make([]reflect.Type, 0, 1)
+	for regType := range c.implementersOfLocked(svcType) {
+		matches = append(matches, regType)
+	}
+	switch len(matches) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.String()
+	}
+	sort.Strings(names)
+	return nil, false, fmt.Errorf("%w, type: %s, candidates: [%s] (use RegisterAs or RegisterImplementation)", ErrAmbiguousResolution, svcType, strings.Join(names, ", "))
+}
+
+// Candidates Debugging aid for ambiguous or failing interface resolution: the concrete types registered (by default or
+// by name) that implement ifaceType, sorted by name and without duplicates. Registrations keyed by an interface are not
+// listed. Returns nil when ifaceType is not an interface
+func (c *Container) Candidates(ifaceType reflect.Type) []reflect.Type {
+	if ifaceType == nil || ifaceType.Kind() != reflect.Interface {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	seen := make(map[reflect.Type]bool)
+	candidates := make([]reflect.Type, 0)
+	add := func(regType reflect.Type) {
+		if regType.Kind() != reflect.Interface && regType.Implements(ifaceType) && !seen[regType] {
+			seen[regType] = true
+			candidates = append(candidates, regType)
+		}
+	}
+	for regType := range c.services {
+		add(regType)
+	}
+	for _, namedMap := range c.namedServices {
+		for regType := range namedMap {
+			add(regType)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].String() < candidates[j].String() })
+	return candidates
+}
+
+// Candidates Implementation candidates on the global container, see ContainerCandidates
+func Candidates[Iface any]() []reflect.Type {
+	return ContainerCandidates[Iface](Global)
+}
+
+// ContainerCandidates Generic version of Container.Candidates
+func ContainerCandidates[Iface any](c *Container) []reflect.Type {
+	return c.Candidates(reflect.TypeOf((*Iface)(nil)).Elem())
+}
+
+// implementersOfLocked Default registrations keyed by a concrete type implementing iface, cached until the next
+// registration so repeated implements-scans skip the Implements checks over all services (caller holds c.mu)
+func (c *Container) implementersOfLocked(iface reflect.Type) map[reflect.Type]bool {
+	c.scanMu.Lock()
+	defer c.scanMu.Unlock()
+	if implementers, ok := c.implementsCache[iface]; ok {
+		return implementers
+	}
+	implementers := make(map[reflect.Type]bool)
+	for regType := range c.services {
+		if regType.Kind() != reflect.Interface && regType.Implements(iface) {
+			implementers[regType] = true
+		}
+	}
+	if c.implementsCache == nil {
+		c.implementsCache = make(map[reflect.Type]map[reflect.Type]bool)
+	}
+	c.implementsCache[iface] = implementers
+	return implementers
+}
+
+// invalidateImplementsLocked Drops the implements-scan and implementation type caches after the set of registrations
+// changed (caller holds the write lock)
+func (c *Container) invalidateImplementsLocked() {
+	c.scanMu.Lock()
+	c.implementsCache = nil
+	c.implTypeCache = nil
+	c.scanMu.Unlock()
+}
+
+// IndexImplTypes Enables/disables resolving a concrete type that is only registered behind an interface (e.g.
+// RegisterAs(NewConsoleLogger, (*ILogger)(nil), ...) resolved as *ConsoleLogger): the interface registration whose
+// implementation type is exactly the requested type is resolved, so both views share one singleton without a second
+// registration. Several such registrations are ambiguous unless they are the same instance (RegisterInstanceAs under
+// several interfaces). Off by default so a concrete type does not silently resolve from an interface registration
+func (c *Container) IndexImplTypes(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.implTypeIndex = enabled
+}
+
+// implTypeKeysLocked Interfaces with a default registration whose implementation type is implType, sorted by name and
+// cached until the next registration (caller holds c.mu)
+func (c *Container) implTypeKeysLocked(implType reflect.Type) []reflect.Type {
+	c.scanMu.Lock()
+	defer c.scanMu.Unlock()
+	if c.implTypeCache == nil {
+		c.implTypeCache = make(map[reflect.Type][]reflect.Type)
+		for regType, def := range c.services {
+			if regType.Kind() == reflect.Interface && def.implType != nil && def.implType != regType {
+				c.implTypeCache[def.implType] = append(c.implTypeCache[def.implType], regType)
+			}
+		}
+		for _, keys := range c.implTypeCache {
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		}
+	}
+	return c.implTypeCache[implType]
+}
+
+// implTypeRegistration Finds the interface registration an unregistered concrete type is the implementation type of
+// (IndexImplTypes); registrations sharing one instance count once, distinct ones are ambiguous
+func (c *Container) implTypeRegistration(svcType reflect.Type) (reflect.Type, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.implTypeIndex || svcType.Kind() == reflect.Interface {
+		return nil, false, nil
+	}
+	keys := c.implTypeKeysLocked(svcType)
+	if len(keys) == 0 {
+		return nil, false, nil
+	}
+	first := c.services[keys[0]]
+	for _, key := range keys[1:] {
+		def := c.services[key]
+		if def == first {
+			continue
+		}
+		if def.isInstance && first.isInstance {
+			if id, ok := instanceIdentity(def.instance); ok {
+				if firstID, ok := instanceIdentity(first.instance); ok && id == firstID {
+					continue
+				}
+			}
+		}
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = k.String()
+		}
+		return nil, false, fmt.Errorf("%w, type: %s, registered as: [%s] (resolve one of the interfaces instead)", ErrAmbiguousResolution, svcType, strings.Join(names, ", "))
+	}
+	return keys[0], true, nil
+}
+
+// underlyingCounterpart Finds the registered defined/underlying counterpart of an unregistered type
+// (MatchUnderlyingTypes); several defined types over the same underlying type are ambiguous
+func (c *Container) underlyingCounterpart(svcType reflect.Type) (reflect.Type, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.underlying || c.strictTypes || svcType.Kind() == reflect.Interface {
+		return nil, false, nil
+	}
+
+	matches := make([]reflect.Type, 0, 1)
+	for regType := range c.services {
+		if isUnderlyingPair(svcType, regType) || isUnderlyingPair(regType, svcType) {
+			matches = append(matches, regType)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.String()
+	}
+	sort.Strings(names)
+	return nil, false, fmt.Errorf("%w, type: %s, candidates: [%s]", ErrAmbiguousResolution, svcType, strings.Join(names, ", "))
+}
+
+// isUnderlyingPair Whether underlying is the underlying type of the defined type: defined types carry a package path,
+// predeclared and unnamed (literal) types do not; same kind plus convertibility then implies identical underlying types
+func isUnderlyingPair(defined, underlying reflect.Type) bool {
+	return defined.PkgPath() != "" && underlying.PkgPath() == "" &&
+		defined.Kind() == underlying.Kind() && defined.ConvertibleTo(underlying)
+}
+
+// Module Provider set: a bundle of registration closures that a library can export and an app installs in one call
+type Module []func(*Container) error
+
+// Install Applies all registrations of a module in order; every registration is attempted and errors are aggregated
+func (c *Container) Install(m Module) error {
+	var errs []error
+	for _, register := range m {
+		if err := register(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ProvideOption Configures Container.ProvideAll behavior; options are passed among the constructors
+type ProvideOption func(*provideOptions)
+
+type provideOptions struct {
+	stopOnError bool // Whether ProvideAll stops at the first failed constructor instead of attempting all of them
+}
+
+// StopOnError ProvideAll option: stop at the first constructor that fails to register (the default attempts every constructor)
+func StopOnError() ProvideOption {
+	return func(o *provideOptions) { o.stopOnError = true }
+}
+
+// Provide Alias of Register in the vocabulary of Wire/fx, identical semantics
+func (c *Container) Provide(ctor any, scope LifetimeScope) error {
+	return c.Register(ctor, scope)
+}
+
+// ProvideAs Alias of RegisterAs, identical semantics
+func (c *Container) ProvideAs(ctor any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) error {
+	return c.RegisterAs(ctor, interfaceType, scope, opts...)
+}
+
+// ProvideAll Registers each constructor with the same lifetime (e.g. a batch of singletons). Every constructor is attempted
+// and errors are aggregated, each naming the failed constructor, unless StopOnError() is passed among ctors
+func (c *Container) ProvideAll(scope LifetimeScope, ctors ...any) error {
+	var options provideOptions
+	for _, ctor := range ctors {
+		if opt, ok := ctor.(ProvideOption); ok {
+			opt(&options)
+		}
+	}
+
+	var errs []error
+	for i, ctor := range ctors {
+		if _, ok := ctor.(ProvideOption); ok {
+			continue
+		}
+		if err := c.Register(ctor, scope); err != nil {
+			errs = append(errs, fmt.Errorf("constructor #%d (%T): %w", i, ctor, err))
+			if options.stopOnError {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterProvidersStruct Registers the providers a module defines as a struct (or pointer to struct): every exported
+// non-nil function field is registered as a constructor with scope, as is every exported method of the value returning
+// exactly one pointer or struct (bound to it, so methods may use the struct's configuration). Nil function fields are
+// providers left unset and skipped, as are non-function fields and other methods (e.g. String, Close). Every provider
+// is attempted and errors are aggregated, each naming the failed field or method
+func (c *Container) RegisterProvidersStruct(providers any, scope LifetimeScope) error {
+	v := reflect.ValueOf(providers)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("providers must be a struct or a pointer to struct, current type: %T", providers)
+	}
+	t := v.Type()
+
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Func || v.Field(i).IsNil() {
+			continue
+		}
+		if err := c.Register(v.Field(i).Interface(), scope); err != nil {
+			errs = append(errs, fmt.Errorf("field %s.%s: %w", t, field.Name, err))
+		}
+	}
+	// Method set of providers as passed: a pointer also contributes its pointer-receiver methods
+	methods := reflect.ValueOf(providers)
+	for i := 0; i < methods.NumMethod(); i++ {
+		if !isProviderMethod(methods.Method(i).Type()) {
+			continue
+		}
+		if err := c.Register(methods.Method(i).Interface(), scope); err != nil {
+			errs = append(errs, fmt.Errorf("method %s.%s: %w", t, methods.Type().Method(i).Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isProviderMethod Whether a bound method looks like a provider for RegisterProvidersStruct: a single pointer or struct
+// return value (variadic methods and basic, interface or error results are not providers)
+func isProviderMethod(methodType reflect.Type) bool {
+	if methodType.NumOut() != 1 || methodType.IsVariadic() {
+		return false
+	}
+	kind := methodType.Out(0).Kind()
+	return kind == reflect.Ptr || kind == reflect.Struct
+}
+
+// MergeOption Configures Container.Merge behavior
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	overwrite bool // Whether registrations from the merged container replace conflicting ones instead of failing
+}
+
+// WithOverwrite Merge option: on conflict the merged (later) container's registration wins instead of returning ErrRegisterDuplicate
+func WithOverwrite() MergeOption {
+	return func(o *mergeOptions) { o.overwrite = true }
+}
+
+// Merge Copies other's default and named registrations into this container (e.g. assembling an app from module containers).
+// Conflicts return ErrRegisterDuplicate (nothing is merged) unless WithOverwrite is given.
+// Constructor-based singleton caches are reset on both sides so they rebuild in the merged context.
+func (c *Container) Merge(other *Container, opts ...MergeOption) error {
+	if other == nil || other == c {
+		return nil
+	}
+	var options mergeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// Snapshot other before locking c: holding both locks would deadlock against a concurrent other.Merge(c)
+	other.mu.RLock()
+	services := make(map[reflect.Type]*ServiceDef, len(other.services))
+	clones := make([]*ServiceDef, 0, len(other.services))
+	clone := func(def *ServiceDef) *ServiceDef {
+		cloned := def.cloneRegistration()
+		clones = append(clones, cloned)
+		return cloned
+	}
+	for svcType, def := range other.services {
+		services[svcType] = clone(def)
+	}
+	namedServices := make(map[string]map[reflect.Type]*ServiceDef, len(other.namedServices))
+	for name, namedMap := range other.namedServices {
+		namedServices[name] = make(map[reflect.Type]*ServiceDef, len(namedMap))
+		for svcType, def := range namedMap {
+			namedServices[name][svcType] = clone(def)
+		}
+	}
+	keyedServices := make(map[any]*ServiceDef, len(other.keyedServices))
+	for id, def := range other.keyedServices {
+		keyedServices[id] = clone(def)
+	}
+	implementations := make(map[reflect.Type][]*ServiceDef, len(other.implementations))
+	for svcType, defs := range other.implementations {
+		for _, def := range defs {
+			implementations[svcType] = append(implementations[svcType], clone(def))
+		}
+	}
+	groups := make(map[string][]*ServiceDef, len(other.groups))
+	for group, defs := range other.groups {
+		for _, def := range defs {
+			groups[group] = append(groups[group], clone(def))
+		}
+	}
+	other.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Check conflicts first so a failed merge leaves the container untouched
+	if !options.overwrite {
+		for svcType, def := range services {
+			if existing, exists := c.services[svcType]; exists {
+				return duplicateError(svcType, existing, def.implType)
+			}
+		}
+		for name, namedMap := range namedServices {
+			for svcType := range namedMap {
+				if _, exists := c.namedServices[c.namedKeyLocked(name)][svcType]; exists {
+					return fmt.Errorf("%w, name: %s, type: %s", ErrRegisterDuplicate, name, svcType)
+				}
+			}
+		}
+		for id, def := range keyedServices {
+			if _, exists := c.keyedServices[id]; exists {
+				return fmt.Errorf("%w, key: %s", ErrRegisterDuplicate, def.name)
+			}
+		}
+	}
+
+	// Merged registrations follow this container's own, keeping other's relative order
+	sort.Slice(clones, func(i, j int) bool { return clones[i].order < clones[j].order })
+	for _, def := range clones {
+		def.order = c.nextOrder()
+	}
+
+	// Reset own singleton caches: dependencies may now resolve differently
+	c.invalidateImplementsLocked()
+	for svcType, def := range c.services {
+		c.services[svcType] = def.cloneRegistration()
+	}
+	for svcType, def := range services {
+		c.services[svcType] = def
+	}
+	for name, namedMap := range namedServices {
+		name = c.namedKeyLocked(name)
+		if c.namedServices[name] == nil {
+			c.namedServices[name] = make(map[reflect.Type]*ServiceDef)
+		}
+		for svcType, def := range namedMap {
+			c.namedServices[name][svcType] = def
+		}
+	}
+	for id, def := range keyedServices {
+		c.keyedServices[id] = def
+	}
+	// Implementations and group members never conflict: the merged ones are appended
+	for svcType, defs := range implementations {
+		c.implementations[svcType] = append(c.implementations[svcType], defs...)
+	}
+	for group, defs := range groups {
+		c.groups[group] = append(c.groups[group], defs...)
+	}
+	return nil
+}
+
+// cloneRegistration Copies registration metadata into a fresh ServiceDef, dropping constructed singleton caches
+// (pre-registered instances are kept since they are the registration itself)
+func (d *ServiceDef) cloneRegistration() *ServiceDef {
+	clone := &ServiceDef{
+		implType:   d.implType,
+		scope:      d.scope,
+		ctor:       d.ctor,
+		ctorType:   d.ctorType,
+		isInstance: d.isInstance,
+		initMethod: d.initMethod,
+		factory:    d.factory,
+		name:       d.name,
+		priority:   d.priority,
+		order:      d.order,
+		primary:    d.primary,
+		cleanup:    d.cleanup,
+		lifetimeFn: d.lifetimeFn,
+		fallible:   d.fallible,
+		internal:   d.internal,
+		via:        d.via,
+		retry:      d.retry,
+	}
+	if d.isInstance {
+		clone.instance = d.instance
+	}
+	return clone
+}
+
+// nextOrder Returns the next registration sequence number and drops the implements-scan cache (caller holds the write lock)
+func (c *Container) nextOrder() int {
+	c.invalidateImplementsLocked()
+	c.registered++
+	return c.registered
+}
+
+// isRegistered Whether svcType has a default (unnamed) registration
+func (c *Container) isRegistered(svcType reflect.Type) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, exists := c.services[svcType]
+	return exists
+}
+
+// Has Feature detection on the global container, see ContainerHas
+func Has[T any]() bool {
+	return ContainerHas[T](Global)
+}
+
+// ContainerHas Whether a default registration can satisfy T: an exact registration, a pointer/value counterpart,
+// a registration whose type is assignable to T or implements interface T, or (for unregistered []Iface) any such
+// implementation. Stronger than an exact-type membership check, intended for feature detection
+func ContainerHas[T any](c *Container) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.hasLocked(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// ScopeHas Same as ContainerHas, additionally considering the scope's (and enclosing scopes') overrides
+func ScopeHas[T any](s *Scope) bool {
+	svcType := reflect.TypeOf((*T)(nil)).Elem()
+	for cur := s; cur != nil; cur = cur.parent {
+		cur.mu.RLock()
+		for t := range cur.overrides {
+			if t == svcType || t.AssignableTo(svcType) || (svcType.Kind() == reflect.Interface && implementsInterface(t, svcType)) {
+				cur.mu.RUnlock()
+				return true
+			}
+		}
+		cur.mu.RUnlock()
+	}
+	// Values bound with BindValue
+	if _, bound := s.lookupScoped(svcType); bound {
+		return true
+	}
+	return ContainerHas[T](s.root)
+}
+
+// hasLocked Implements ContainerHas for a reflect type (caller holds c.mu)
+func (c *Container) hasLocked(svcType reflect.Type) bool {
+	if _, exists := c.services[svcType]; exists {
+		return true
+	}
+	if _, ok := c.pointerCounterpartLocked(svcType); ok {
+		return true
+	}
+	if c.implTypeIndex && len(c.implTypeKeysLocked(svcType)) > 0 {
+		return true
+	}
+	target := svcType
+	if svcType.Kind() == reflect.Slice && svcType.Elem().Kind() == reflect.Interface {
+		target = svcType.Elem()
+	}
+	for t := range c.services {
+		if t.AssignableTo(target) || (target.Kind() == reflect.Interface && implementsInterface(t, target)) {
+			return true
+		}
+	}
+	for t, impls := range c.implementations {
+		if len(impls) > 0 && (t.AssignableTo(target) || (target.Kind() == reflect.Interface && implementsInterface(t, target))) {
+			return true
+		}
+	}
+	return false
+}
+
+// pointerCounterpart Finds a registered pointer/value counterpart of svcType (*T for T, T for *T)
+// that satisfies the same isTypeCompatible rules used at registration.
+// Requesting *T of a value singleton T is always allowed (its cached storage is addressable, so the pointer is stable);
+// all other adaptations require SetAutoDeref(true).
+func (c *Container) pointerCounterpart(svcType reflect.Type) (reflect.Type, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pointerCounterpartLocked(svcType)
+}
+
+// pointerCounterpartLocked Same as pointerCounterpart, caller holds c.mu
+func (c *Container) pointerCounterpartLocked(svcType reflect.Type) (reflect.Type, bool) {
+	var altType reflect.Type
+	if svcType.Kind() == reflect.Ptr {
+		altType = svcType.Elem()
+	} else if svcType.Kind() != reflect.Interface {
+		altType = reflect.PointerTo(svcType)
+	} else {
+		return nil, false
+	}
+
+	altDef, exists := c.services[altType]
+	enabled := c.autoDeref || (exists && svcType.Kind() == reflect.Ptr && altDef.scope == Singleton)
+	if !enabled || !exists || !isTypeCompatible(altType, svcType) {
+		return nil, false
+	}
+	return altType, true
+}
+
+// addressable Moves a non-pointer value into addressable storage so cached singletons/instances can hand out a stable address
+func addressable(v reflect.Value) reflect.Value {
+	if !v.IsValid() || v.CanAddr() || v.Kind() == reflect.Ptr {
+		return v
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr.Elem()
+}
+
+// adaptPointerValue Adapts a resolved instance to its pointer/value counterpart type (deref or take address)
+func adaptPointerValue(instance reflect.Value, targetType reflect.Type) (reflect.Value, error) {
+	it := instance.Type()
+	if it.AssignableTo(targetType) {
+		return instance, nil
+	}
+	// Pointer registered, value requested: dereference
+	if it.Kind() == reflect.Ptr && it.Elem().AssignableTo(targetType) {
+		if instance.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%w, cannot dereference nil %s into %s", ErrTypeConvertFailed, it, targetType)
+		}
+		return instance.Elem(), nil
+	}
+	// Value registered, pointer requested: take address (copy if not addressable)
+	if targetType.Kind() == reflect.Ptr && it.AssignableTo(targetType.Elem()) {
+		if instance.CanAddr() {
+			return instance.Addr(), nil
+		}
+		ptr := reflect.New(targetType.Elem())
+		ptr.Elem().Set(instance)
+		return ptr, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w, instance %s cannot be adapted to %s", ErrTypeConvertFailed, it, targetType)
+}
+
+// Resolve Original resolution: receives instance through pointer, returns error (compatible with old logic)
+func (c *Container) Resolve(out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+	// Fast path: pre-registered Singleton instance has no dependencies, skip resolution state allocation
+	if instance, ok := c.instanceFastPath(svcType); ok {
+		outVal.Elem().Set(instance)
+		return nil
+	}
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, err := c.resolve(svcType, track)
+	if err != nil {
+		return err
+	}
+	outVal.Elem().Set(instance)
+	return nil
+}
+
+// instanceFastPath Returns a pre-registered Singleton instance for svcType without allocating resolution state
+// (instances are immutable after registration and have no dependencies to track); disabled while transforms are set
+func (c *Container) instanceFastPath(svcType reflect.Type) (reflect.Value, bool) {
+	c.mu.RLock()
+	serviceDef, exists := c.services[svcType]
+	transformed := len(c.transforms) > 0
+	c.mu.RUnlock()
+	if !exists || transformed || !serviceDef.isInstance || serviceDef.scope != Singleton {
+		return reflect.Value{}, false
+	}
+	// A nil stored instance takes the regular path, which reports ErrNilInstance
+	instance, err := serviceDef.storedInstance()
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	c.recordResolve(serviceDef)
+	return instance, true
+}
+
+// ResolveContext Context-aware resolution: constructors (and init methods) taking context.Context receive ctx
+// instead of requiring a registered context, e.g. so dialing constructors respect cancellation and deadlines
+func (c *Container) ResolveContext(ctx context.Context, out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+	track := newResolveTrack(ctx)
+	defer track.release()
+	instance, err := c.resolve(svcType, track)
+	if err != nil {
+		return err
+	}
+	outVal.Elem().Set(instance)
+	return nil
+}
+
+// Invoke Calls fn with its parameters resolved from the container (special parameters such as context.Context,
+// ServiceName, Resolver and In structs are supported). fn may return nothing or a single error. A parameter that cannot
+// be resolved fails with ErrInvokeFailed without calling fn; an error returned by fn itself is returned unwrapped
+func (c *Container) Invoke(fn any) error {
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func || fnVal.IsNil() {
+		return ErrNotFunc
+	}
+	fnType := fnVal.Type()
+	if fnType.NumOut() > 1 || (fnType.NumOut() == 1 && fnType.Out(0) != errorType) {
+		return fmt.Errorf("%w, invoked function may only return error: %s", ErrNotFunc, fnType)
+	}
+
+	// Resolve arguments like a constructor's, through a transient definition of fn
+	def := &ServiceDef{implType: fnType, ctor: fnVal, ctorType: fnType}
+	track := newResolveTrack(nil)
+	defer track.release()
+	params, err := def.resolveArgs(c, def.params(), c.resolveParams, track)
+	if err != nil {
+		return fmt.Errorf("%w, %s: %w", ErrInvokeFailed, fnType, err)
+	}
+	results := fnVal.Call(params)
+	if len(results) == 1 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+	return nil
+}
+
+// Populate Field injection: target must be a non-nil pointer to struct. Exported fields tagged `inject:""` are resolved
+// by type, `inject:"name"` resolves a named service; embedded (anonymous) interface fields are injected by their interface
+// type even without a tag. `inject:"-"` skips a field.
+func (c *Container) Populate(target any) error {
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Ptr || targetVal.IsNil() || targetVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w, Populate target must be a pointer to struct", ErrInvalidOutPtr)
+	}
+	structVal := targetVal.Elem()
+	structType := structVal.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, tagged := field.Tag.Lookup("inject")
+		if tag == "-" {
+			continue
+		}
+		embeddedIface := field.Anonymous && field.Type.Kind() == reflect.Interface
+		if !tagged && !embeddedIface {
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("cannot inject unexported field %s.%s", structType, field.Name)
+		}
+
+		fieldPtr := structVal.Field(i).Addr().Interface()
+		var err error
+		if tag != "" {
+			err = c.ResolveNamed(tag, fieldPtr)
+		} else {
+			err = c.Resolve(fieldPtr)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to inject field %s.%s: %w", structType, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// PopulateNamed Named field injection: target must be a non-nil pointer to struct. Each exported field tagged
+// `name:"x"` is filled from the named registration x through ResolveNamed (e.g. a fixed set of named DB connections);
+// untagged fields are left alone. Stops at the first field that cannot be resolved
+func (c *Container) PopulateNamed(target any) error {
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Ptr || targetVal.IsNil() || targetVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w, PopulateNamed target must be a pointer to struct", ErrInvalidOutPtr)
+	}
+	structVal := targetVal.Elem()
+	structType := structVal.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := field.Tag.Get("name")
+		if name == "" {
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("cannot inject unexported field %s.%s", structType, field.Name)
+		}
+		if err := c.ResolveNamed(name, structVal.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("failed to inject field %s.%s (name %q): %w", structType, field.Name, name, err)
+		}
+	}
 	return nil
 }
 
+// ResolveMany Batch resolution: resolves each out pointer in turn (sharing one dependency track), every out is attempted and errors are joined
+func (c *Container) ResolveMany(outs ...any) error {
+	track := newResolveTrack(nil)
+	defer track.release()
+	var errs []error
+	for i, out := range outs {
+		outVal := reflect.ValueOf(out)
+		if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+			errs = append(errs, fmt.Errorf("out #%d: %w", i, ErrInvalidOutPtr))
+			continue
+		}
+		instance, err := c.resolve(outVal.Elem().Type(), track)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("out #%d: %w", i, err))
+			continue
+		}
+		outVal.Elem().Set(instance)
+	}
+	return errors.Join(errs...)
+}
+
+// ResolveDetailed Same as Resolve, additionally reports whether the instance was reused from cache
+// (cached singleton or pre-registered instance) or freshly constructed (useful to spot accidental rebuilds)
+func (c *Container) ResolveDetailed(out any) (fromCache bool, err error) {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return false, ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, fromCache, err := c.resolveDetailed(svcType, track)
+	if err != nil {
+		return false, err
+	}
+	if instance, err = c.finishResolve(svcType, instance, fromCache); err != nil {
+		return false, err
+	}
+	outVal.Elem().Set(instance)
+	return fromCache, nil
+}
+
 // ResolveNamed Named resolution: resolves specific service instance by name
 func (c *Container) ResolveNamed(name string, out any) error {
 	outVal := reflect.ValueOf(out)
@@ -328,10 +2855,10 @@ func (c *Container) ResolveNamed(name string, out any) error {
 	svcType := outVal.Elem().Type()
 
 	c.mu.RLock()
-	namedMap, exists := c.namedServices[name]
+	namedMap, exists := c.namedServices[c.namedKeyLocked(name)]
 	if !exists {
 		c.mu.RUnlock()
-		return fmt.Errorf("named service does not exist, name: %s", name)
+		return fmt.Errorf("%w, named service does not exist, name: %s", ErrServiceNotRegistered, name)
 	}
 	serviceDef, exists := namedMap[svcType]
 	c.mu.RUnlock()
@@ -340,102 +2867,696 @@ func (c *Container) ResolveNamed(name string, out any) error {
 		return fmt.Errorf("%w, name: %s, type: %s", ErrServiceNotRegistered, name, svcType)
 	}
 
-	// Named services currently only support instance registration, return instance directly
+	// Instance registration: return instance directly
 	if serviceDef.isInstance {
-		outVal.Elem().Set(serviceDef.instance)
+		instance, err := serviceDef.storedInstance()
+		if err != nil {
+			return err
+		}
+		outVal.Elem().Set(instance)
 		return nil
 	}
 
-	return fmt.Errorf("named services do not support constructor registration yet, name: %s", name)
+	instance, err := c.resolveNamedDef(serviceDef)
+	if err != nil {
+		return err
+	}
+	outVal.Elem().Set(instance)
+	return nil
+}
+
+// resolveNamedDef Resolves a named constructor registration on the root container (Scoped must be resolved through a Scope)
+func (c *Container) resolveNamedDef(serviceDef *ServiceDef) (reflect.Value, error) {
+	track := newResolveTrack(nil)
+	defer track.release()
+	return c.resolveDef(serviceDef, track)
+}
+
+// scopedOnly Whether a registration can only be built inside a scope (a Scoped constructor): collections on the root
+// container skip it up front, while every other failure, including a Scoped dependency, is reported
+func scopedOnly(serviceDef *ServiceDef) bool {
+	return !serviceDef.isInstance && serviceDef.lifetime() == Scoped
+}
+
+// resolveDef Resolves a registration that is not keyed by type alone (named, keyed, implementation) within an ongoing resolution
+func (c *Container) resolveDef(serviceDef *ServiceDef, track *resolveTrack) (reflect.Value, error) {
+	c.recordResolve(serviceDef)
+	lifetime := serviceDef.lifetime()
+	if serviceDef.isInstance {
+		return serviceDef.storedInstance()
+	}
+	if lifetime == Scoped {
+		return reflect.Value{}, ErrScopedOnRootContainer
+	}
+	if lifetime == Singleton {
+		if inst, ok := c.cachedSingleton(serviceDef); ok {
+			return inst, nil
+		}
+	}
+	outer := track.beginBuild(ResolutionInfo{Type: serviceDef.implType, Lifetime: lifetime, Name: serviceDef.name})
+	instance, err := serviceDef.construct(c, c.resolveParams, track)
+	track.info = outer
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if lifetime == Singleton {
+		instance = c.storeSingleton(nil, serviceDef, instance)
+	}
+	return instance, nil
+}
+
+// cachedSingleton Returns the constructed instance of a Singleton registration if it has been built. The cache is only
+// written by storeSingleton under c.mu, so unsynchronized readers never observe a partially written reflect.Value
+func (c *Container) cachedSingleton(serviceDef *ServiceDef) (reflect.Value, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return serviceDef.instance, serviceDef.instance.IsValid()
+}
+
+// defaultSingleton Returns the instance of the default Singleton registration of svcType if the container has cached it,
+// or adopts it from the SingletonStore if the store has it
+func (c *Container) defaultSingleton(svcType reflect.Type, serviceDef *ServiceDef) (reflect.Value, bool) {
+	if inst, ok := c.cachedSingleton(serviceDef); ok {
+		return inst, true
+	}
+	c.mu.RLock()
+	store := c.singletonStore
+	c.mu.RUnlock()
+	if store == nil {
+		return reflect.Value{}, false
+	}
+	stored, ok := store.Get(svcType)
+	if !ok || !stored.IsValid() {
+		return reflect.Value{}, false
+	}
+	serviceDef.once.Do(func() {
+		c.mu.Lock()
+		serviceDef.instance = addressable(stored)
+		c.mu.Unlock()
+	})
+	return serviceDef.instance, true
+}
+
+// storeSingleton Caches a constructed Singleton exactly once (the first constructed instance wins) and returns the cached
+// instance; OnFirstInit callbacks of svcType fire on the first store and the SingletonStore receives it (svcType is nil
+// for named/keyed registrations)
+func (c *Container) storeSingleton(svcType reflect.Type, serviceDef *ServiceDef, instance reflect.Value) reflect.Value {
+	serviceDef.once.Do(func() {
+		c.mu.Lock()
+		serviceDef.instance = addressable(instance)
+		store := c.singletonStore
+		c.mu.Unlock()
+		if svcType != nil {
+			if store != nil {
+				store.Set(svcType, serviceDef.instance)
+			}
+			c.fireFirstInit(svcType, serviceDef.instance)
+		}
+	})
+	// once.Do orders the store before every return, so reading after it is safe
+	return serviceDef.instance
+}
+
+// ResolveAllOption Configures Container.ResolveAll
+type ResolveAllOption func(*resolveAllOptions)
+
+type resolveAllOptions struct {
+	includeParents bool
+}
+
+// IncludeParents ResolveAll option for child containers: the matching services of the parent chain are appended after
+// the child's own (child first, each container in its own priority order), skipping instances already collected
+// (same pointer for reference types, equal value otherwise), e.g. plugin handlers followed by the base handlers
+func IncludeParents() ResolveAllOption {
+	return func(o *resolveAllOptions) { o.includeParents = true }
+}
+
+// ResolveAllOptions Selects and orders the services collected by ResolveAllOpts. The zero value collects named instances
+// and additional implementations only, in registration order; ResolveAll uses DefaultResolveAllOptions()
+type ResolveAllOptions struct {
+	Ordered             bool // Sort by ascending priority (WithPriority), registration order for ties; otherwise registration order only
+	Unique              bool // Return an instance registered several times once, at its first position (pointer identity or equal value)
+	IncludeDefault      bool // Include default (unnamed) registrations: of the element type, and of concrete types implementing an interface element type
+	IncludeConstructors bool // Also resolve constructor-backed default/named registrations, not only pre-registered instances
 }
 
-// ResolveAll Resolves all services of the same type (including default and all named services)
-func (c *Container) ResolveAll(out any) error {
+// DefaultResolveAllOptions Options of ResolveAll: ordered, default registration included, instances only (additional
+// implementations are always resolved). A fresh value is returned on each call, so callers may adjust it freely
+func DefaultResolveAllOptions() ResolveAllOptions {
+	return ResolveAllOptions{Ordered: true, IncludeDefault: true}
+}
+
+// ResolveAll Resolves all services of the same type (including default and all named services, and additional
+// implementations registered with RegisterImplementation). For an interface element type, instances registered under
+// concrete types implementing it are included as well (once per instance)
+func (c *Container) ResolveAll(out any, opts ...ResolveAllOption) error {
+	_, err := c.resolveAll(out, DefaultResolveAllOptions(), opts)
+	return err
+}
+
+// ResolveAllWithNames Same as ResolveAll, additionally returning the registration name of each collected instance:
+// names[i] belongs to (*out)[i], "" for the default registration, additional implementations and other unnamed ones
+func (c *Container) ResolveAllWithNames(out any, opts ...ResolveAllOption) ([]string, error) {
+	owners, err := c.resolveAll(out, DefaultResolveAllOptions(), opts)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(owners))
+	for i, def := range owners {
+		names[i] = def.name
+	}
+	return names, nil
+}
+
+// ResolveAllOpts Configurable ResolveAll: options select ordering, deduplication and which registrations are collected.
+// Scoped constructors are skipped on the root container
+func (c *Container) ResolveAllOpts(out any, options ResolveAllOptions) error {
+	_, err := c.resolveAll(out, options, nil)
+	return err
+}
+
+// ResolveAllUnique Same as ResolveAll, but an instance registered several times (e.g. as default and under names) is
+// returned once, at its first position. Identity is pointer identity for reference types and equality for comparable values
+func (c *Container) ResolveAllUnique(out any) error {
+	options := DefaultResolveAllOptions()
+	options.Unique = true
+	_, err := c.resolveAll(out, options, nil)
+	return err
+}
+
+// resolveAll Shared implementation of ResolveAll, ResolveAllOpts, ResolveAllUnique and ResolveAllWithNames, returning the
+// registration that produced each collected instance (index-aligned with out)
+func (c *Container) resolveAll(out any, options ResolveAllOptions, opts []ResolveAllOption) ([]*ServiceDef, error) {
 	outVal := reflect.ValueOf(out)
 	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
-		return ErrInvalidOutPtr
+		return nil, ErrInvalidOutPtr
 	}
 
 	// Check output type must be a slice pointer
 	elemType := outVal.Elem().Type()
 	if elemType.Kind() != reflect.Slice {
-		return fmt.Errorf("ResolveAll output parameter must be a slice pointer, current type: %s", elemType)
+		return nil, fmt.Errorf("ResolveAll output parameter must be a slice pointer, current type: %s", elemType)
 	}
 
 	// Get slice element type
 	itemType := elemType.Elem()
+	include := func(d *ServiceDef) bool { return d.isInstance || options.IncludeConstructors }
 
 	c.mu.RLock()
-	defer c.mu.RUnlock()
 
-	// Create result slice
-	results := reflect.MakeSlice(elemType, 0, 0)
+	// Collect default service (if exists), all named services and additional implementations
+	defs := make([]*ServiceDef, 0)
+	defaultDef := c.services[itemType]
+	if defaultDef != nil && options.IncludeDefault && include(defaultDef) {
+		defs = append(defs, defaultDef)
+	}
+	for _, namedMap := range c.namedServices {
+		if serviceDef, exists := namedMap[itemType]; exists && include(serviceDef) {
+			defs = append(defs, serviceDef)
+		}
+	}
+	defs = append(defs, c.implementations[itemType]...)
+	// Interface element type: also registrations keyed by implementing concrete types
+	implementers, defaults := c.implementersLocked(itemType, include)
+	if !options.IncludeDefault {
+		named := implementers[:0]
+		for _, def := range implementers {
+			if _, isDefault := defaults[def]; !isDefault {
+				named = append(named, def)
+			}
+		}
+		implementers = named
+	}
+	defs = append(defs, implementers...)
+	c.mu.RUnlock()
 
-	// Add default service (if exists)
-	if serviceDef, exists := c.services[itemType]; exists {
-		if serviceDef.isInstance {
-			results = reflect.Append(results, serviceDef.instance)
+	// Sort ascending by priority (when ordered), registration order for ties
+	sort.SliceStable(defs, func(i, j int) bool {
+		if options.Ordered && defs[i].priority != defs[j].priority {
+			return defs[i].priority < defs[j].priority
+		}
+		return defs[i].order < defs[j].order
+	})
+
+	// Create result slice (constructors are resolved, Scoped ones skipped on the root container)
+	track := newResolveTrack(nil)
+	defer track.release()
+	results := reflect.MakeSlice(elemType, 0, len(defs))
+	owners := make([]*ServiceDef, 0, len(defs))
+	for _, serviceDef := range defs {
+		if scopedOnly(serviceDef) {
+			continue
+		}
+		var instance reflect.Value
+		var err error
+		if regType, isDefault := defaults[serviceDef]; isDefault && !serviceDef.isInstance {
+			instance, err = c.resolve(regType, track)
+		} else if serviceDef == defaultDef && !serviceDef.isInstance {
+			instance, err = c.resolve(itemType, track)
+		} else {
+			instance, err = c.resolveDef(serviceDef, track)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve implementation %s: %w", serviceDef.implType, err)
 		}
+		if instance, err = convertInstance(itemType, instance, false); err != nil {
+			return nil, err
+		}
+		results = reflect.Append(results, instance)
+		owners = append(owners, serviceDef)
+	}
+	results, owners = dedupeImplementers(results, owners, implementers)
+	if options.Unique {
+		results, owners = uniqueInstances(results, owners)
 	}
 
-	// Add all named services
-	for _, namedMap := range c.namedServices {
-		if serviceDef, exists := namedMap[itemType]; exists {
-			if serviceDef.isInstance {
-				results = reflect.Append(results, serviceDef.instance)
+	var parents resolveAllOptions
+	for _, opt := range opts {
+		opt(&parents)
+	}
+	if parents.includeParents && c.parent != nil {
+		parentResults := reflect.New(elemType)
+		parentOwners, err := c.parent.resolveAll(parentResults.Interface(), options, opts)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[any]bool, results.Len())
+		for i := 0; i < results.Len(); i++ {
+			if id, ok := instanceIdentity(results.Index(i)); ok {
+				seen[id] = true
+			}
+		}
+		for i := 0; i < parentResults.Elem().Len(); i++ {
+			item := parentResults.Elem().Index(i)
+			if id, ok := instanceIdentity(item); ok && seen[id] {
+				continue
 			}
+			results = reflect.Append(results, item)
+			owners = append(owners, parentOwners[i])
 		}
 	}
 
 	// Set result
+	outVal.Elem().Set(results)
+	return owners, nil
+}
+
+// uniqueInstances Keeps the first occurrence of each instance identity; values without a usable identity are all kept.
+// owners[i] produced results[i], the returned owners stay aligned with the returned results
+func uniqueInstances(results reflect.Value, owners []*ServiceDef) (reflect.Value, []*ServiceDef) {
+	unique := reflect.MakeSlice(results.Type(), 0, results.Len())
+	uniqueOwners := make([]*ServiceDef, 0, len(owners))
+	seen := make(map[any]bool, results.Len())
+	for i := 0; i < results.Len(); i++ {
+		item := results.Index(i)
+		if id, ok := instanceIdentity(item); ok {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		unique = reflect.Append(unique, item)
+		uniqueOwners = append(uniqueOwners, owners[i])
+	}
+	return unique, uniqueOwners
+}
+
+// mergeSlice Returns a copy of base followed by the elements of extra whose identity is not already present; elements
+// without a usable identity are always appended
+func mergeSlice(base, extra reflect.Value) reflect.Value {
+	merged := reflect.MakeSlice(base.Type(), 0, base.Len()+extra.Len())
+	merged = reflect.AppendSlice(merged, base)
+	seen := make(map[any]bool, merged.Len())
+	for i := 0; i < merged.Len(); i++ {
+		if id, ok := instanceIdentity(merged.Index(i)); ok {
+			seen[id] = true
+		}
+	}
+	for i := 0; i < extra.Len(); i++ {
+		item := extra.Index(i)
+		if id, ok := instanceIdentity(item); ok {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		merged = reflect.Append(merged, item)
+	}
+	return merged
+}
+
+// implementersLocked Registrations (default and named, accepted by include) keyed by a concrete type implementing the
+// interface itemType, for ResolveAll and slice auto-collection of an interface element type, sorted by registration
+// order; defaults maps the default ones to their registered type. Empty for non-interface item types (caller holds c.mu)
+func (c *Container) implementersLocked(itemType reflect.Type, include func(*ServiceDef) bool) ([]*ServiceDef, map[*ServiceDef]reflect.Type) {
+	if itemType.Kind() != reflect.Interface {
+		return nil, nil
+	}
+	defs := make([]*ServiceDef, 0)
+	defaults := make(map[*ServiceDef]reflect.Type)
+	for regType, def := range c.services {
+		if isImplementer(regType, def, itemType) && include(def) {
+			defs = append(defs, def)
+			defaults[def] = regType
+		}
+	}
+	for _, namedMap := range c.namedServices {
+		for regType, def := range namedMap {
+			if isImplementer(regType, def, itemType) && include(def) {
+				defs = append(defs, def)
+			}
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].order < defs[j].order })
+	return defs, defaults
+}
+
+// isImplementer Whether the registration def keyed by regType is an implementer of the interface itemType: both the
+// registered type and the implementation type are concrete and implement it
+func isImplementer(regType reflect.Type, def *ServiceDef, itemType reflect.Type) bool {
+	if regType.Kind() == reflect.Interface || def.implType == nil || def.implType.Kind() == reflect.Interface {
+		return false
+	}
+	return def.implType.Implements(itemType) && regType.Implements(itemType)
+}
+
+// appendImplementers Appends the resolved implementers (implementersLocked) to results, skipping instances already
+// collected; build resolves one registration. On the root container (onRoot) Scoped constructors are skipped
+func appendImplementers(results reflect.Value, implementers []*ServiceDef, onRoot bool, build func(*ServiceDef) (reflect.Value, error)) (reflect.Value, error) {
+	if len(implementers) == 0 {
+		return results, nil
+	}
+	itemType := results.Type().Elem()
+	owners := make([]*ServiceDef, results.Len(), results.Len()+len(implementers))
+	for _, serviceDef := range implementers {
+		if onRoot && scopedOnly(serviceDef) {
+			continue
+		}
+		instance, err := build(serviceDef)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("failed to resolve implementation %s: %w", serviceDef.implType, err)
+		}
+		if instance, err = convertInstance(itemType, instance, false); err != nil {
+			return reflect.Value{}, err
+		}
+		results = reflect.Append(results, instance)
+		owners = append(owners, serviceDef)
+	}
+	results, _ = dedupeImplementers(results, owners, implementers)
+	return results, nil
+}
+
+// dedupeImplementers Drops the results of implementer registrations (implementersLocked) whose instance is collected
+// anyway, e.g. an instance registered both as the interface and as its concrete type; owners[i] produced results[i],
+// the returned owners stay aligned with the returned results
+func dedupeImplementers(results reflect.Value, owners, implementers []*ServiceDef) (reflect.Value, []*ServiceDef) {
+	if len(implementers) == 0 {
+		return results, owners
+	}
+	scanned := make(map[*ServiceDef]bool, len(implementers))
+	for _, def := range implementers {
+		scanned[def] = true
+	}
+	seen := make(map[any]bool, results.Len())
+	for i := 0; i < results.Len(); i++ {
+		if id, ok := instanceIdentity(results.Index(i)); ok && !scanned[owners[i]] {
+			seen[id] = true
+		}
+	}
+	unique := reflect.MakeSlice(results.Type(), 0, results.Len())
+	uniqueOwners := make([]*ServiceDef, 0, len(owners))
+	for i := 0; i < results.Len(); i++ {
+		item := results.Index(i)
+		if id, ok := instanceIdentity(item); ok && scanned[owners[i]] {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+		}
+		unique = reflect.Append(unique, item)
+		uniqueOwners = append(uniqueOwners, owners[i])
+	}
+	return unique, uniqueOwners
+}
+
+// instanceIdentity Identity key of a resolved instance for deduplication; ok is false for values without a usable identity
+func instanceIdentity(v reflect.Value) (any, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		type identity struct {
+			t reflect.Type
+			p uintptr
+		}
+		return identity{v.Type(), v.Pointer()}, true
+	}
+	if v.Comparable() && v.CanInterface() {
+		return v.Interface(), true
+	}
+	return nil, false
+}
+
+// ResolveAllByTypeName Resolves all implementations of an interface into *map[string]T keyed by concrete Go type name
+// (e.g. "RedisCache"), unlike name-keyed maps which use the registration name. Types sharing a simple name across packages
+// are keyed by full path ("pkg/path.RedisCache"); for several registrations of the same type the first one is kept.
+func (c *Container) ResolveAllByTypeName(out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+
+	// Check output type must be map[string]Interface pointer
+	mapType := outVal.Elem().Type()
+	if mapType.Kind() != reflect.Map || mapType.Key().Kind() != reflect.String || mapType.Elem().Kind() != reflect.Interface {
+		return fmt.Errorf("ResolveAllByTypeName output parameter must be a map[string]Interface pointer, current type: %s", mapType)
+	}
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	instances, err := c.collectImplementations(reflect.SliceOf(mapType.Elem()), track)
+	if err != nil {
+		return err
+	}
+
+	// Count distinct types per simple name to detect collisions
+	typesByName := make(map[string]map[reflect.Type]bool)
+	for i := 0; i < instances.Len(); i++ {
+		implType := concreteType(instances.Index(i).Elem().Type())
+		if typesByName[implType.Name()] == nil {
+			typesByName[implType.Name()] = make(map[reflect.Type]bool)
+		}
+		typesByName[implType.Name()][implType] = true
+	}
+
+	results := reflect.MakeMap(mapType)
+	for i := 0; i < instances.Len(); i++ {
+		inst := instances.Index(i)
+		implType := concreteType(inst.Elem().Type())
+		key := implType.Name()
+		if len(typesByName[key]) > 1 {
+			key = implType.PkgPath() + "." + implType.Name()
+		}
+		keyVal := reflect.ValueOf(key).Convert(mapType.Key())
+		if results.MapIndex(keyVal).IsValid() {
+			continue
+		}
+		results.SetMapIndex(keyVal, inst)
+	}
+
 	outVal.Elem().Set(results)
 	return nil
 }
 
+// concreteType Strips pointer indirections to get the named concrete type
+func concreteType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
 // resolve Internal recursive resolution core method: handles dependencies, caching, lifetime (original logic with added Scoped validation)
-func (c *Container) resolve(svcType reflect.Type, track map[reflect.Type]bool) (reflect.Value, error) {
+func (c *Container) resolve(svcType reflect.Type, track *resolveTrack) (reflect.Value, error) {
+	parent, node := track.beginTrace(svcType)
+	instance, fromCache, err := c.resolveDetailed(svcType, track)
+	track.endTrace(parent, node, fromCache)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return c.finishResolve(svcType, instance, fromCache)
+}
+
+// resolveDetailed Same as resolve, additionally reports whether the instance came from a cache/pre-registered instance (true) or was freshly constructed (false)
+func (c *Container) resolveDetailed(svcType reflect.Type, track *resolveTrack) (reflect.Value, bool, error) {
 	// Read lock to get service definition, avoid write blocking
-	c.mu.RLock()
-	serviceDef, exists := c.services[svcType]
-	c.mu.RUnlock()
+	serviceDef, exists, err := c.registration(svcType)
+	if err != nil {
+		return reflect.Value{}, false, err
+	}
 	if !exists {
-		return reflect.Value{}, fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, svcType)
+		// Fallback: pointer/value counterpart is registered, resolve it and adapt
+		if altType, ok := c.pointerCounterpart(svcType); ok {
+			inst, fromCache, err := c.resolveDetailed(altType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			adapted, err := adaptPointerValue(inst, svcType)
+			return adapted, fromCache, err
+		}
+		// Opt-in: interface registration implemented by exactly this type
+		if ifaceType, ok, err := c.implTypeRegistration(svcType); err != nil {
+			return reflect.Value{}, false, err
+		} else if ok {
+			inst, fromCache, err := c.resolveDetailed(ifaceType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			conv, err := convertInstance(svcType, inst, false)
+			return conv, fromCache, err
+		}
+		// Opt-in: single registration implementing the interface
+		if implType, ok, err := c.scanImplementation(svcType); err != nil {
+			return reflect.Value{}, false, err
+		} else if ok {
+			inst, fromCache, err := c.resolveDetailed(implType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			return inst.Convert(svcType), fromCache, nil
+		}
+		// Opt-in: defined type <-> underlying type
+		if altType, ok, err := c.underlyingCounterpart(svcType); err != nil {
+			return reflect.Value{}, false, err
+		} else if ok {
+			inst, fromCache, err := c.resolveDetailed(altType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			return inst.Convert(svcType), fromCache, nil
+		}
+		// Child container: resolve from the parent chain, the child's fallback only covers what the parents lack
+		if c.parent != nil {
+			inst, fromCache, err := c.parent.resolveDetailed(svcType, track)
+			if err == nil || !errors.Is(err, ErrServiceNotRegistered) {
+				return inst, fromCache, err
+			}
+			if fallbackInst, fallbackErr := c.resolveFallback(svcType); !errors.Is(fallbackErr, ErrServiceNotRegistered) {
+				return fallbackInst, false, fallbackErr
+			}
+			return reflect.Value{}, false, err
+		}
+		inst, err := c.resolveFallback(svcType)
+		return inst, false, err
 	}
 
-	// Circular dependency detection
-	if track[svcType] {
-		return reflect.Value{}, fmt.Errorf("%w, circular dependency chain contains: %s", ErrResolveCircularDependency, svcType)
+	c.recordResolve(serviceDef)
+	lifetime := serviceDef.lifetime()
+
+	// Circular dependency detection (a pointer singleton under two-phase construction completes the cycle)
+	if track.visiting[svcType] {
+		if partial, ok := track.cyclePartial(svcType); ok {
+			return partial, true, nil
+		}
+		return reflect.Value{}, false, fmt.Errorf("%w, circular dependency chain contains: %s", ErrResolveCircularDependency, svcType)
 	}
-	track[svcType] = true
-	defer delete(track, svcType)
+	track.visiting[svcType] = true
+	defer delete(track.visiting, svcType)
 
 	// New: Scoped prohibits direct resolution from root container, must use scope
-	if serviceDef.scope == Scoped {
-		return reflect.Value{}, ErrScopedOnRootContainer
+	if lifetime == Scoped {
+		return reflect.Value{}, false, ErrScopedOnRootContainer
+	}
+
+	// Instance registration: directly return pre-registered instance (Singleton/Scoped)
+	if serviceDef.isInstance {
+		instance, err := serviceDef.storedInstance()
+		return instance, true, err
+	}
+
+	// Singleton: return existing instance directly
+	if lifetime == Singleton {
+		if inst, ok := c.defaultSingleton(svcType, serviceDef); ok {
+			return inst, true, nil
+		}
+	}
+
+	// Cache miss: create instance (factory or constructor + init method), two-phase with AllowPointerCycles
+	partial := c.beginPartial(svcType, serviceDef, lifetime, track)
+	outer := track.beginBuild(ResolutionInfo{Type: svcType, Lifetime: lifetime, Name: serviceDef.name})
+	instance, err := serviceDef.construct(c, c.resolveParams, track)
+	track.info = outer
+	instance, err = c.endPartial(svcType, partial, instance, err, track)
+	if err != nil {
+		return reflect.Value{}, false, err
+	}
+
+	// Singleton: atomic operation to cache instance, ensure created only once
+	if lifetime == Singleton {
+		instance = c.storeSingleton(svcType, serviceDef, instance)
+		track.recordSingleton(svcType, serviceDef)
+	}
+
+	return instance, false, nil
+}
+
+// collectSlice Auto-collects the elements of slice type pType: the default registration of the element type, named
+// instances of it and additional implementations (RegisterImplementation), in that order
+func (c *Container) collectSlice(pType reflect.Type, track *resolveTrack) (reflect.Value, error) {
+	elemType := pType.Elem()
+
+	// Create result slice
+	results := reflect.MakeSlice(pType, 0, 0)
+
+	// Add default service (if exists)
+	c.mu.RLock()
+	if _, exists := c.services[elemType]; exists {
+		c.mu.RUnlock()
+		// Recursively resolve default instance
+		inst, err := c.resolve(elemType, track)
+		if err == nil {
+			results = reflect.Append(results, inst)
+		}
+	} else {
+		c.mu.RUnlock()
 	}
 
-	// Instance registration: directly return pre-registered instance (Singleton/Scoped)
-	if serviceDef.isInstance {
-		return serviceDef.instance, nil
+	// Add all named services
+	c.mu.RLock()
+	for _, namedMap := range c.namedServices {
+		if namedServiceDef, exists := namedMap[elemType]; exists {
+			if namedServiceDef.isInstance {
+				results = reflect.Append(results, namedServiceDef.instance)
+			}
+		}
 	}
+	c.mu.RUnlock()
 
-	// Singleton: return existing instance directly
-	if serviceDef.scope == Singleton && serviceDef.instance.IsValid() {
-		return serviceDef.instance, nil
+	// Add additional implementations (RegisterImplementation)
+	impls, err := c.implementationValues(elemType, track)
+	if err != nil {
+		return reflect.Value{}, err
 	}
+	results = reflect.Append(results, impls...)
 
-	// Core optimization: cache constructor parameter types, parse only on first resolution
-	serviceDef.paramOnce.Do(func() {
-		numIn := serviceDef.ctorType.NumIn()
-		params := make([]reflect.Type, numIn)
-		for i := 0; i < numIn; i++ {
-			params[i] = serviceDef.ctorType.In(i)
+	// Add registrations keyed by concrete types implementing an interface element type, as ResolveAll does
+	c.mu.RLock()
+	implementers, defaults := c.implementersLocked(elemType, func(d *ServiceDef) bool { return d.isInstance })
+	c.mu.RUnlock()
+	return appendImplementers(results, implementers, true, func(serviceDef *ServiceDef) (reflect.Value, error) {
+		if regType, isDefault := defaults[serviceDef]; isDefault {
+			return c.resolve(regType, track)
 		}
-		serviceDef.paramTypes = params
+		return c.resolveDef(serviceDef, track)
 	})
-	paramTypes := serviceDef.paramTypes
+}
 
-	// Recursively resolve all dependency parameters
+// resolveParams Resolves parameter values in order (with slice/map auto-collection); shared by constructors and init methods
+func (c *Container) resolveParams(paramTypes []reflect.Type, track *resolveTrack) ([]reflect.Value, error) {
 	params := make([]reflect.Value, len(paramTypes))
 	for i, pType := range paramTypes {
 		// Check if parameter is a slice type
@@ -449,41 +3570,35 @@ func (c *Container) resolve(svcType reflect.Type, track map[reflect.Type]bool) (
 				// Slice type is registered, resolve directly
 				pInstance, err := c.resolve(pType, track)
 				if err != nil {
-					return reflect.Value{}, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+					return nil, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+				}
+				if c.mergesSlices() {
+					// MergeRegisteredSlices: append the auto-collected elements the registered slice lacks
+					collected, err := c.collectSlice(pType, track)
+					if err != nil {
+						return nil, err
+					}
+					pInstance = mergeSlice(pInstance, collected)
 				}
 				params[i] = pInstance
 			} else {
 				// Slice type not registered: automatically collect all instances of that element type
-				elemType := pType.Elem()
-
-				// Create result slice
-				results := reflect.MakeSlice(pType, 0, 0)
-
-				// Add default service (if exists)
-				c.mu.RLock()
-				if _, exists := c.services[elemType]; exists {
-					c.mu.RUnlock()
-					// Recursively resolve default instance
-					inst, err := c.resolve(elemType, track)
-					if err == nil {
-						results = reflect.Append(results, inst)
-					}
-				} else {
-					c.mu.RUnlock()
+				results, err := c.collectSlice(pType, track)
+				if err != nil {
+					return nil, err
 				}
-
-				// Add all named services
-				c.mu.RLock()
-				for _, namedMap := range c.namedServices {
-					if namedServiceDef, exists := namedMap[elemType]; exists {
-						if namedServiceDef.isInstance {
-							results = reflect.Append(results, namedServiceDef.instance)
-						}
-					}
+				if params[i], err = c.emptySlice(results); err != nil {
+					return nil, err
 				}
-				c.mu.RUnlock()
-
-				params[i] = results
+			}
+		} else if pType.Kind() == reflect.Array && !c.isRegistered(pType) {
+			// Unregistered array: collect like a slice of the element type, the count must match the length
+			collected, err := c.resolveParams([]reflect.Type{reflect.SliceOf(pType.Elem())}, track)
+			if err != nil {
+				return nil, err
+			}
+			if params[i], err = arrayFromSlice(pType, collected[0]); err != nil {
+				return nil, err
 			}
 		} else if pType.Kind() == reflect.Map && pType.Key().Kind() == reflect.String {
 			// Check if parameter is map[string]T type
@@ -496,7 +3611,7 @@ func (c *Container) resolve(svcType reflect.Type, track map[reflect.Type]bool) (
 				// map type is registered, resolve directly
 				pInstance, err := c.resolve(pType, track)
 				if err != nil {
-					return reflect.Value{}, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+					return nil, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
 				}
 				params[i] = pInstance
 			} else {
@@ -506,6 +3621,19 @@ func (c *Container) resolve(svcType reflect.Type, track map[reflect.Type]bool) (
 				// Create result map
 				results := reflect.MakeMap(pType)
 
+				// Add default service under the configured key (SetMapDefaultKey); named services below override it
+				c.mu.RLock()
+				_, hasDefault := c.services[valueType]
+				hasDefault = hasDefault && c.mapDefault
+				defaultKey := c.mapDefaultKey
+				c.mu.RUnlock()
+				if hasDefault {
+					inst, err := c.resolve(valueType, track)
+					if err == nil {
+						results.SetMapIndex(reflect.ValueOf(defaultKey).Convert(pType.Key()), inst)
+					}
+				}
+
 				// Collect all named services
 				c.mu.RLock()
 				for name, namedMap := range c.namedServices {
@@ -524,27 +3652,23 @@ func (c *Container) resolve(svcType reflect.Type, track map[reflect.Type]bool) (
 			// Non-slice/map type: normal resolution
 			pInstance, err := c.resolve(pType, track)
 			if err != nil {
-				return reflect.Value{}, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+				return nil, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
 			}
 			params[i] = pInstance
 		}
 	}
+	return params, nil
+}
 
-	// Call constructor to create instance
-	results := serviceDef.ctor.Call(params)
-	if len(results) != 1 {
-		return reflect.Value{}, fmt.Errorf("%w, constructor call returned abnormal value", ErrCreateInstanceFailed)
-	}
-	instance := results[0]
-
-	// Singleton: atomic operation to cache instance, ensure created only once
-	if serviceDef.scope == Singleton {
-		serviceDef.once.Do(func() {
-			serviceDef.instance = instance
-		})
+// arrayFromSlice Copies auto-collected services into a fixed-size array, failing with ErrArrayLengthMismatch unless
+// exactly as many services as the array length were collected
+func arrayFromSlice(arrayType reflect.Type, collected reflect.Value) (reflect.Value, error) {
+	if collected.Len() != arrayType.Len() {
+		return reflect.Value{}, fmt.Errorf("%w, %s requires %d services, %d registered", ErrArrayLengthMismatch, arrayType, arrayType.Len(), collected.Len())
 	}
-
-	return instance, nil
+	array := reflect.New(arrayType).Elem()
+	reflect.Copy(array, collected)
+	return array, nil
 }
 
 // NewScope New: Container creates scope method (root container exclusive, creates Scoped scope)
@@ -555,6 +3679,170 @@ func (c *Container) NewScope() *Scope {
 	}
 }
 
+// NewChild Creates a child container (e.g. per plugin) with its own registrations and settings: a type not registered
+// in the child is resolved by the parent chain, constructed and cached there with the parent's dependencies.
+// ResolveAll with IncludeParents also collects the parents' services. Scopes of the child only see the child's registrations
+func (c *Container) NewChild() *Container {
+	child := NewContainer()
+	child.parent = c
+	return child
+}
+
+// NamedScope Returns the persistent scope registered under tag (e.g. "tenant:acme"), creating it on first use, so repeated
+// calls reuse the same Scoped instances per tag. Release it with DisposeNamedScope
+func (c *Container) NamedScope(tag string) *Scope {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if scope, exists := c.namedScopes[tag]; exists {
+		return scope
+	}
+	if c.namedScopes == nil {
+		c.namedScopes = make(map[string]*Scope)
+	}
+	scope := c.NewScope()
+	c.namedScopes[tag] = scope
+	return scope
+}
+
+// DisposeNamedScope Removes the persistent scope of tag and disposes it (see Scope.Dispose); the next NamedScope(tag)
+// starts a fresh scope. Returns false if no scope exists for tag, and the aggregated release errors
+func (c *Container) DisposeNamedScope(tag string) (bool, error) {
+	c.mu.Lock()
+	scope, exists := c.namedScopes[tag]
+	delete(c.namedScopes, tag)
+	c.mu.Unlock()
+	if !exists {
+		return false, nil
+	}
+	return true, scope.Dispose()
+}
+
+// NewScope Creates a nested child scope. Inheritance rule: resolving a Scoped type in the child reuses the instance
+// already built in the child or its nearest enclosing scope; only when absent in all of them is a new instance created,
+// owned and cached by the child (so it is never visible to the parent or sibling scopes). Overrides of enclosing scopes
+// also apply in the child unless the child overrides the same type itself
+func (s *Scope) NewScope() *Scope {
+	return &Scope{
+		root:       s.root,
+		scopedInst: make(map[reflect.Type]reflect.Value),
+		parent:     s,
+	}
+}
+
+// lookupScoped Finds a resolved Scoped instance in this scope or its enclosing scopes (nearest first)
+func (s *Scope) lookupScoped(svcType reflect.Type) (reflect.Value, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		cur.mu.RLock()
+		inst, exists := cur.scopedInst[svcType]
+		cur.mu.RUnlock()
+		if exists && inst.IsValid() {
+			return inst, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// lookupOverride Finds an override in this scope or its enclosing scopes (nearest first)
+func (s *Scope) lookupOverride(svcType reflect.Type) (reflect.Value, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		cur.mu.RLock()
+		inst, exists := cur.overrides[svcType]
+		cur.mu.RUnlock()
+		if exists {
+			return inst, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// Clone Forks the scope: the clone starts with a shallow copy of this scope's resolved Scoped instances
+// and shares the same root container; Scoped instances created afterwards in either scope are not visible to the other.
+// Instances copied from the parent stay owned by the parent (tracked in inherited) and must only be disposed by it.
+func (s *Scope) Clone() *Scope {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	clone := &Scope{
+		root:       s.root,
+		parent:     s.parent,
+		scopedInst: make(map[reflect.Type]reflect.Value, len(s.scopedInst)),
+		inherited:  make(map[reflect.Type]bool, len(s.scopedInst)),
+	}
+	for t, inst := range s.scopedInst {
+		clone.scopedInst[t] = inst
+		clone.inherited[t] = true
+	}
+	if len(s.scopedDefs) > 0 {
+		clone.scopedDefs = make(map[*ServiceDef]reflect.Value, len(s.scopedDefs))
+		for def, inst := range s.scopedDefs {
+			clone.scopedDefs[def] = inst
+		}
+	}
+	if len(s.overrides) > 0 {
+		clone.overrides = make(map[reflect.Type]reflect.Value, len(s.overrides))
+		for t, inst := range s.overrides {
+			clone.overrides[t] = inst
+		}
+	}
+	return clone
+}
+
+// Override Shadows the registration of the instance's type for resolutions in this scope only
+// (e.g. a test double or tenant-specific client); the root container and other scopes are unaffected
+func (s *Scope) Override(instance any) error {
+	return s.override(instance, nil)
+}
+
+// OverrideAs Shadows the registration of the given interface/concrete type (nil pointer, as in RegisterInstanceAs) in this scope only
+func (s *Scope) OverrideAs(instance any, interfaceType any) error {
+	return s.override(instance, interfaceType)
+}
+
+// override Internal scope override logic
+func (s *Scope) override(instance any, interfaceType any) error {
+	if isNilInstance(instance) {
+		return ErrNilInstance
+	}
+	instVal := reflect.ValueOf(instance)
+	svcType, err := instanceServiceType(instVal.Type(), interfaceType)
+	if err != nil {
+		return err
+	}
+	instVal = conformInstance(instVal, svcType)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overrides == nil {
+		s.overrides = make(map[reflect.Type]reflect.Value)
+	}
+	s.overrides[svcType] = addressable(instVal)
+	return nil
+}
+
+// BindValue Stores a request value (e.g. the authenticated *User or a tenant ID type bound by middleware) in this scope,
+// keyed by its dynamic type, so constructors resolved in this scope and its nested scopes can depend on it although
+// the type is not registered. For a type registered as Scoped it pre-fills this scope's instance; types registered with
+// another lifetime are rejected (use Override to shadow them). Binding the same type again replaces the value
+func (s *Scope) BindValue(v any) error {
+	if v == nil {
+		return ErrNilInstance
+	}
+	val := reflect.ValueOf(v)
+	t := val.Type()
+
+	s.root.mu.RLock()
+	serviceDef, registered := s.root.services[t]
+	s.root.mu.RUnlock()
+	if registered && serviceDef.scope != Scoped {
+		return fmt.Errorf("%w, type: %s is registered with a non-Scoped lifetime (use Override)", ErrRegisterDuplicate, t)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scopedInst[t] = addressable(val)
+	delete(s.inherited, t)
+	return nil
+}
+
 // Resolve New: Scope's Resolve method (consistent format with Container's Resolve, supports Scoped)
 func (s *Scope) Resolve(out any) error {
 	outVal := reflect.ValueOf(out)
@@ -562,7 +3850,157 @@ func (s *Scope) Resolve(out any) error {
 		return ErrInvalidOutPtr
 	}
 	svcType := outVal.Elem().Type()
-	instance, err := s.resolve(svcType, make(map[reflect.Type]bool))
+	// Fast path: pre-registered Singleton instance (unless overridden in this scope)
+	if instance, ok := s.instanceFastPath(svcType); ok {
+		outVal.Elem().Set(instance)
+		return nil
+	}
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, err := s.resolve(svcType, track)
+	if err != nil {
+		return err
+	}
+	outVal.Elem().Set(instance)
+	return nil
+}
+
+// instanceFastPath Scope version of the instance fast path, scope overrides take precedence
+func (s *Scope) instanceFastPath(svcType reflect.Type) (reflect.Value, bool) {
+	if _, overridden := s.lookupOverride(svcType); overridden {
+		return reflect.Value{}, false
+	}
+	return s.root.instanceFastPath(svcType)
+}
+
+// ResolveDetailed Scope version of ResolveDetailed: fromCache is true for cached singletons, this scope's cached Scoped instances and pre-registered instances
+func (s *Scope) ResolveDetailed(out any) (fromCache bool, err error) {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return false, ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, fromCache, err := s.resolveDetailed(svcType, track)
+	if err != nil {
+		return false, err
+	}
+	if instance, err = s.root.finishResolve(svcType, instance, fromCache); err != nil {
+		return false, err
+	}
+	outVal.Elem().Set(instance)
+	return fromCache, nil
+}
+
+// ResolveContext Scope version of context-aware resolution: ctx is injected into context.Context parameters and
+// checked before each constructor call, so a canceled request stops building scoped services
+func (s *Scope) ResolveContext(ctx context.Context, out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+	track := newResolveTrack(ctx)
+	defer track.release()
+	instance, err := s.resolve(svcType, track)
+	if err != nil {
+		return err
+	}
+	outVal.Elem().Set(instance)
+	return nil
+}
+
+// ResolveAll Scope version of Container.ResolveAll: besides instances and additional implementations it includes
+// Scoped registrations (default and named) of the element type, resolving them into this scope. Ordering is the same
+// (ascending priority, then registration order); each registration contributes exactly one element and repeated calls
+// return the same Scoped instances. The default registration honors scope overrides
+func (s *Scope) ResolveAll(out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	elemType := outVal.Elem().Type()
+	if elemType.Kind() != reflect.Slice {
+		return fmt.Errorf("ResolveAll output parameter must be a slice pointer, current type: %s", elemType)
+	}
+	itemType := elemType.Elem()
+
+	s.root.mu.RLock()
+	defaultDef := s.root.services[itemType]
+	defs := make([]*ServiceDef, 0)
+	if defaultDef != nil && (defaultDef.isInstance || defaultDef.scope == Scoped) {
+		defs = append(defs, defaultDef)
+	}
+	for _, namedMap := range s.root.namedServices {
+		if serviceDef, exists := namedMap[itemType]; exists && (serviceDef.isInstance || serviceDef.scope == Scoped) {
+			defs = append(defs, serviceDef)
+		}
+	}
+	defs = append(defs, s.root.implementations[itemType]...)
+	implementers, defaults := s.root.implementersLocked(itemType, func(d *ServiceDef) bool { return d.isInstance || d.scope == Scoped })
+	defs = append(defs, implementers...)
+	s.root.mu.RUnlock()
+
+	sort.SliceStable(defs, func(i, j int) bool {
+		if defs[i].priority != defs[j].priority {
+			return defs[i].priority < defs[j].priority
+		}
+		return defs[i].order < defs[j].order
+	})
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	results := reflect.MakeSlice(elemType, 0, len(defs))
+	owners := make([]*ServiceDef, 0, len(defs))
+	for _, serviceDef := range defs {
+		var instance reflect.Value
+		var err error
+		if regType, isDefault := defaults[serviceDef]; isDefault {
+			instance, err = s.resolve(regType, track)
+		} else if serviceDef == defaultDef {
+			instance, err = s.resolve(itemType, track)
+		} else {
+			instance, err = s.resolveDef(serviceDef, track)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve implementation %s: %w", serviceDef.implType, err)
+		}
+		if instance, err = convertInstance(itemType, instance, false); err != nil {
+			return err
+		}
+		results = reflect.Append(results, instance)
+		owners = append(owners, serviceDef)
+	}
+	results, _ = dedupeImplementers(results, owners, implementers)
+	outVal.Elem().Set(results)
+	return nil
+}
+
+// ResolveNamed Scope version of named resolution: a named Scoped constructor is built once per scope and name (nested
+// scopes reuse the enclosing scope's instance, sibling scopes get their own); other lifetimes behave as on the container
+func (s *Scope) ResolveNamed(name string, out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+
+	s.root.mu.RLock()
+	namedMap, exists := s.root.namedServices[s.root.namedKeyLocked(name)]
+	if !exists {
+		s.root.mu.RUnlock()
+		return fmt.Errorf("%w, named service does not exist, name: %s", ErrServiceNotRegistered, name)
+	}
+	serviceDef, exists := namedMap[svcType]
+	s.root.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("%w, name: %s, type: %s", ErrServiceNotRegistered, name, svcType)
+	}
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, err := s.resolveDef(serviceDef, track)
 	if err != nil {
 		return err
 	}
@@ -570,82 +4008,276 @@ func (s *Scope) Resolve(out any) error {
 	return nil
 }
 
+// paramResolver Resolves the dependencies of an instance built in this scope: Singletons are cached on the root
+// container for every caller, so they only see root registrations (never this scope's overrides, bound values or
+// Scoped instances); other lifetimes resolve through the scope. inScope reports the latter
+func (s *Scope) paramResolver(lifetime LifetimeScope) (resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error), inScope bool) {
+	if lifetime == Singleton {
+		return s.root.resolveParams, false
+	}
+	return s.resolveParams, true
+}
+
+// resolveDef Scope version of Container.resolveDef for registrations not reachable by type alone (named,
+// implementation): Scoped ones are cached per registration (name+type) in this scope, visible to nested scopes
+func (s *Scope) resolveDef(serviceDef *ServiceDef, track *resolveTrack) (reflect.Value, error) {
+	s.root.recordResolve(serviceDef)
+	lifetime := serviceDef.lifetime()
+	if serviceDef.isInstance {
+		return serviceDef.storedInstance()
+	}
+	switch lifetime {
+	case Singleton:
+		if inst, ok := s.root.cachedSingleton(serviceDef); ok {
+			return inst, nil
+		}
+	case Scoped:
+		for cur := s; cur != nil; cur = cur.parent {
+			cur.mu.RLock()
+			inst, exists := cur.scopedDefs[serviceDef]
+			cur.mu.RUnlock()
+			if exists {
+				return inst, nil
+			}
+		}
+	}
+
+	resolveParams, inScope := s.paramResolver(lifetime)
+	outer := track.beginBuild(ResolutionInfo{Type: serviceDef.implType, Lifetime: lifetime, Name: serviceDef.name, InScope: inScope})
+	instance, err := serviceDef.construct(s.root, resolveParams, track)
+	track.info = outer
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	switch lifetime {
+	case Singleton:
+		instance = s.root.storeSingleton(nil, serviceDef, instance)
+	case Scoped:
+		s.mu.Lock()
+		if s.scopedDefs == nil {
+			s.scopedDefs = make(map[*ServiceDef]reflect.Value)
+		}
+		s.scopedDefs[serviceDef] = instance
+		s.mu.Unlock()
+	}
+	return instance, nil
+}
+
 // New: Scope's internal resolution method (handles all lifetimes, core Scoped caching logic)
-func (s *Scope) resolve(svcType reflect.Type, track map[reflect.Type]bool) (reflect.Value, error) {
+func (s *Scope) resolve(svcType reflect.Type, track *resolveTrack) (reflect.Value, error) {
+	parent, node := track.beginTrace(svcType)
+	instance, fromCache, err := s.resolveDetailed(svcType, track)
+	track.endTrace(parent, node, fromCache)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return s.root.finishResolve(svcType, instance, fromCache)
+}
+
+// resolveDetailed Same as resolve, additionally reports whether the instance came from a cache/pre-registered instance (true) or was freshly constructed (false)
+func (s *Scope) resolveDetailed(svcType reflect.Type, track *resolveTrack) (reflect.Value, bool, error) {
+	// Scope-local (or enclosing scope) override shadows the root registration
+	if override, overridden := s.lookupOverride(svcType); overridden {
+		return override, true, nil
+	}
+
 	// Get registration metadata from root container (shared by all scopes)
 	s.root.mu.RLock()
-	serviceDef, exists := s.root.services[svcType]
+	_, hasDefault := s.root.services[svcType]
 	s.root.mu.RUnlock()
+	if !hasDefault {
+		// Value bound to this scope or an enclosing one (BindValue)
+		if bound, ok := s.lookupScoped(svcType); ok {
+			return bound, true, nil
+		}
+	}
+	serviceDef, exists, err := s.root.registration(svcType)
+	if err != nil {
+		return reflect.Value{}, false, err
+	}
 	if !exists {
-		return reflect.Value{}, fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, svcType)
+		// Fallback: pointer/value counterpart is registered, resolve it and adapt
+		if altType, ok := s.root.pointerCounterpart(svcType); ok {
+			inst, fromCache, err := s.resolveDetailed(altType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			adapted, err := adaptPointerValue(inst, svcType)
+			return adapted, fromCache, err
+		}
+		// Opt-in: interface registration implemented by exactly this type
+		if ifaceType, ok, err := s.root.implTypeRegistration(svcType); err != nil {
+			return reflect.Value{}, false, err
+		} else if ok {
+			inst, fromCache, err := s.resolveDetailed(ifaceType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			conv, err := convertInstance(svcType, inst, false)
+			return conv, fromCache, err
+		}
+		// Opt-in: single registration implementing the interface
+		if implType, ok, err := s.root.scanImplementation(svcType); err != nil {
+			return reflect.Value{}, false, err
+		} else if ok {
+			inst, fromCache, err := s.resolveDetailed(implType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			return inst.Convert(svcType), fromCache, nil
+		}
+		// Opt-in: defined type <-> underlying type
+		if altType, ok, err := s.root.underlyingCounterpart(svcType); err != nil {
+			return reflect.Value{}, false, err
+		} else if ok {
+			inst, fromCache, err := s.resolveDetailed(altType, track)
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			return inst.Convert(svcType), fromCache, nil
+		}
+		inst, err := s.root.resolveFallback(svcType)
+		return inst, false, err
 	}
 
-	// Circular dependency detection
-	if track[svcType] {
-		return reflect.Value{}, fmt.Errorf("%w, circular dependency chain contains: %s", ErrResolveCircularDependency, svcType)
+	s.root.recordResolve(serviceDef)
+	lifetime := serviceDef.lifetime()
+
+	// Circular dependency detection (a pointer singleton under two-phase construction completes the cycle)
+	if track.visiting[svcType] {
+		if partial, ok := track.cyclePartial(svcType); ok {
+			return partial, true, nil
+		}
+		return reflect.Value{}, false, fmt.Errorf("%w, circular dependency chain contains: %s", ErrResolveCircularDependency, svcType)
 	}
-	track[svcType] = true
-	defer delete(track, svcType)
+	track.visiting[svcType] = true
+	defer delete(track.visiting, svcType)
 
 	// Instance registration handling
 	if serviceDef.isInstance {
+		if _, err := serviceDef.storedInstance(); err != nil {
+			return reflect.Value{}, false, err
+		}
 		// Singleton instance: directly return root container's instance
-		if serviceDef.scope == Singleton {
-			return serviceDef.instance, nil
+		if lifetime == Singleton {
+			return serviceDef.instance, true, nil
 		}
 		// Scoped instance: each scope has independent cache
-		if serviceDef.scope == Scoped {
+		if lifetime == Scoped {
 			s.mu.RLock()
 			inst, exists := s.scopedInst[svcType]
 			s.mu.RUnlock()
 			if exists && inst.IsValid() {
-				return inst, nil
+				return inst, true, nil
 			}
 			// First access: cache instance to scope
 			s.mu.Lock()
 			s.scopedInst[svcType] = serviceDef.instance
 			s.mu.Unlock()
-			return serviceDef.instance, nil
+			return serviceDef.instance, true, nil
+		}
+	}
+
+	// 1. Singleton: fix circular dependency → prioritize getting cache from root container, if not initialized use scope's own resolve (reuse track)
+	if lifetime == Singleton {
+		// Return root container's cached singleton directly (core: skip root container resolve, avoid duplicate track writes)
+		if inst, ok := s.root.defaultSingleton(svcType, serviceDef); ok {
+			return inst, true, nil
+		}
+		// Singleton not initialized: built below from root registrations only (reuse current track, no circular dependency
+		// false positive), so scope overrides and bound values never leak into the globally cached instance
+		goto createInstance
+	}
+
+	// 2. Scoped: unique within scope, check this scope's cache first, then enclosing scopes (nested scope inheritance)
+	if lifetime == Scoped {
+		if inst, exists := s.lookupScoped(svcType); exists {
+			return inst, true, nil
 		}
 	}
 
-	// 1. Singleton: fix circular dependency → prioritize getting cache from root container, if not initialized use scope's own resolve (reuse track)
-	if serviceDef.scope == Singleton {
-		// Read lock to get root container's singleton instance, return directly if cached (core: skip root container resolve, avoid duplicate track writes)
-		s.root.mu.RLock()
-		if serviceDef.instance.IsValid() {
-			inst := serviceDef.instance
-			s.root.mu.RUnlock()
-			return inst, nil
+	// New label: unified instance creation (Scoped/Transient/uninitialized Singleton shared)
+createInstance:
+	// Cache miss: create instance (Scoped/Transient/uninitialized Singleton common), two-phase with AllowPointerCycles
+	partial := s.root.beginPartial(svcType, serviceDef, lifetime, track)
+	resolveParams, inScope := s.paramResolver(lifetime)
+	outer := track.beginBuild(ResolutionInfo{Type: svcType, Lifetime: lifetime, Name: serviceDef.name, InScope: inScope})
+	instance, err := serviceDef.construct(s.root, resolveParams, track)
+	track.info = outer
+	instance, err = s.root.endPartial(svcType, partial, instance, err, track)
+	if err != nil {
+		return reflect.Value{}, false, err
+	}
+
+	// 3. Scoped: write instance to this scope's cache
+	if lifetime == Scoped {
+		s.mu.Lock()
+		s.scopedInst[svcType] = instance
+		s.mu.Unlock()
+	}
+
+	// New: uninitialized Singleton, write to root container cache after creation (ensure global uniqueness)
+	if lifetime == Singleton {
+		instance = s.root.storeSingleton(svcType, serviceDef, instance)
+		track.recordSingleton(svcType, serviceDef)
+	}
+
+	// 4. Transient: return directly, no caching
+	return instance, false, nil
+}
+
+// collectSlice Scope version of slice auto-collection
+func (s *Scope) collectSlice(pType reflect.Type, track *resolveTrack) (reflect.Value, error) {
+	elemType := pType.Elem()
+
+	// Create result slice
+	results := reflect.MakeSlice(pType, 0, 0)
+
+	// Add default service (if exists)
+	s.root.mu.RLock()
+	if _, exists := s.root.services[elemType]; exists {
+		s.root.mu.RUnlock()
+		// Recursively resolve default instance
+		inst, err := s.resolve(elemType, track)
+		if err == nil {
+			results = reflect.Append(results, inst)
 		}
+	} else {
 		s.root.mu.RUnlock()
-		// Singleton not initialized: use scope's own resolve to complete initialization (reuse current track, no circular dependency false positive)
-		goto createInstance
 	}
 
-	// 2. Scoped: unique within scope, check this scope's cache first
-	if serviceDef.scope == Scoped {
-		s.mu.RLock()
-		inst, exists := s.scopedInst[svcType]
-		s.mu.RUnlock()
-		if exists && inst.IsValid() {
-			return inst, nil
+	// Add all named services
+	s.root.mu.RLock()
+	for _, namedMap := range s.root.namedServices {
+		if namedServiceDef, exists := namedMap[elemType]; exists {
+			if namedServiceDef.isInstance {
+				results = reflect.Append(results, namedServiceDef.instance)
+			}
 		}
 	}
+	s.root.mu.RUnlock()
+
+	// Add additional implementations (RegisterImplementation)
+	impls, err := s.root.implementationValues(elemType, track)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	results = reflect.Append(results, impls...)
 
-	// New label: unified instance creation (Scoped/Transient/uninitialized Singleton shared)
-createInstance:
-	// Cache miss: resolve parameters + create instance (Scoped/Transient/uninitialized Singleton common)
-	serviceDef.paramOnce.Do(func() {
-		numIn := serviceDef.ctorType.NumIn()
-		params := make([]reflect.Type, numIn)
-		for i := 0; i < numIn; i++ {
-			params[i] = serviceDef.ctorType.In(i)
+	// Add registrations keyed by concrete types implementing an interface element type, as ResolveAll does
+	s.root.mu.RLock()
+	implementers, defaults := s.root.implementersLocked(elemType, func(d *ServiceDef) bool { return d.isInstance || d.scope == Scoped })
+	s.root.mu.RUnlock()
+	return appendImplementers(results, implementers, false, func(serviceDef *ServiceDef) (reflect.Value, error) {
+		if regType, isDefault := defaults[serviceDef]; isDefault {
+			return s.resolve(regType, track)
 		}
-		serviceDef.paramTypes = params
+		return s.resolveDef(serviceDef, track)
 	})
-	paramTypes := serviceDef.paramTypes
+}
 
+// resolveParams Scope version of parameter resolution (with slice/map auto-collection); shared by constructors and init methods
+func (s *Scope) resolveParams(paramTypes []reflect.Type, track *resolveTrack) ([]reflect.Value, error) {
 	params := make([]reflect.Value, len(paramTypes))
 	for i, pType := range paramTypes {
 		// Check if parameter is a slice type
@@ -659,41 +4291,35 @@ createInstance:
 				// Slice type is registered, resolve directly
 				pInstance, err := s.resolve(pType, track)
 				if err != nil {
-					return reflect.Value{}, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+					return nil, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+				}
+				if s.root.mergesSlices() {
+					// MergeRegisteredSlices: append the auto-collected elements the registered slice lacks
+					collected, err := s.collectSlice(pType, track)
+					if err != nil {
+						return nil, err
+					}
+					pInstance = mergeSlice(pInstance, collected)
 				}
 				params[i] = pInstance
 			} else {
 				// Slice type not registered: automatically collect all instances of that element type
-				elemType := pType.Elem()
-
-				// Create result slice
-				results := reflect.MakeSlice(pType, 0, 0)
-
-				// Add default service (if exists)
-				s.root.mu.RLock()
-				if _, exists := s.root.services[elemType]; exists {
-					s.root.mu.RUnlock()
-					// Recursively resolve default instance
-					inst, err := s.resolve(elemType, track)
-					if err == nil {
-						results = reflect.Append(results, inst)
-					}
-				} else {
-					s.root.mu.RUnlock()
+				results, err := s.collectSlice(pType, track)
+				if err != nil {
+					return nil, err
 				}
-
-				// Add all named services
-				s.root.mu.RLock()
-				for _, namedMap := range s.root.namedServices {
-					if namedServiceDef, exists := namedMap[elemType]; exists {
-						if namedServiceDef.isInstance {
-							results = reflect.Append(results, namedServiceDef.instance)
-						}
-					}
+				if params[i], err = s.root.emptySlice(results); err != nil {
+					return nil, err
 				}
-				s.root.mu.RUnlock()
-
-				params[i] = results
+			}
+		} else if pType.Kind() == reflect.Array && !s.root.isRegistered(pType) {
+			// Unregistered array: collect like a slice of the element type, the count must match the length
+			collected, err := s.resolveParams([]reflect.Type{reflect.SliceOf(pType.Elem())}, track)
+			if err != nil {
+				return nil, err
+			}
+			if params[i], err = arrayFromSlice(pType, collected[0]); err != nil {
+				return nil, err
 			}
 		} else if pType.Kind() == reflect.Map && pType.Key().Kind() == reflect.String {
 			// Check if parameter is map[string]T type
@@ -706,7 +4332,7 @@ createInstance:
 				// map type is registered, resolve directly
 				pInstance, err := s.resolve(pType, track)
 				if err != nil {
-					return reflect.Value{}, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+					return nil, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
 				}
 				params[i] = pInstance
 			} else {
@@ -716,6 +4342,19 @@ createInstance:
 				// Create result map
 				results := reflect.MakeMap(pType)
 
+				// Add default service under the configured key (SetMapDefaultKey); named services below override it
+				s.root.mu.RLock()
+				_, hasDefault := s.root.services[valueType]
+				hasDefault = hasDefault && s.root.mapDefault
+				defaultKey := s.root.mapDefaultKey
+				s.root.mu.RUnlock()
+				if hasDefault {
+					inst, err := s.resolve(valueType, track)
+					if err == nil {
+						results.SetMapIndex(reflect.ValueOf(defaultKey).Convert(pType.Key()), inst)
+					}
+				}
+
 				// Collect all named services
 				s.root.mu.RLock()
 				for name, namedMap := range s.root.namedServices {
@@ -734,152 +4373,387 @@ createInstance:
 			// Non-slice/map type: normal resolution
 			pInstance, err := s.resolve(pType, track)
 			if err != nil {
-				return reflect.Value{}, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
+				return nil, fmt.Errorf("failed to resolve dependency %s: %w", pType, err)
 			}
 			params[i] = pInstance
 		}
 	}
+	return params, nil
+}
 
-	results := serviceDef.ctor.Call(params)
-	if len(results) != 1 {
-		return reflect.Value{}, fmt.Errorf("%w, constructor call returned abnormal value", ErrCreateInstanceFailed)
-	}
-	instance := results[0]
-
-	// 3. Scoped: write instance to this scope's cache
-	if serviceDef.scope == Scoped {
-		s.mu.Lock()
-		s.scopedInst[svcType] = instance
-		s.mu.Unlock()
+// getTyped Internal generic resolution: converts reflection-obtained instance to target type T
+func getTyped[T any](c *Container, svcType reflect.Type, instance reflect.Value) (T, error) {
+	var zero T
+	conv, err := convertInstance(svcType, instance, c != nil && c.isStrict())
+	if err != nil {
+		return zero, err
 	}
+	// Set through reflection instead of a type assertion so nil interface values yield the zero value
+	var result T
+	reflect.ValueOf(&result).Elem().Set(conv)
+	return result, nil
+}
 
-	// New: uninitialized Singleton, write to root container cache after creation (ensure global uniqueness)
-	if serviceDef.scope == Singleton {
-		serviceDef.once.Do(func() {
-			s.root.mu.Lock()
-			serviceDef.instance = instance
-			s.root.mu.Unlock()
-		})
+// convertInstance Converts a resolved instance to a value assignable to svcType (interface implementation, assignable or
+// convertible types; convertible types are rejected when strict)
+func convertInstance(svcType reflect.Type, instance reflect.Value, strict bool) (reflect.Value, error) {
+	// Interface-typed value (e.g. produced as IA but requested as IB): re-assert using its dynamic value
+	if instance.Kind() == reflect.Interface && !instance.Type().AssignableTo(svcType) {
+		if instance.IsNil() {
+			return reflect.Value{}, fmt.Errorf("[%w] nil %s instance cannot be converted to target type %s", ErrTypeConvertFailed, instance.Type(), svcType)
+		}
+		instance = instance.Elem()
 	}
 
-	// 4. Transient: return directly, no caching
-	return instance, nil
-}
-
-// getTyped Internal generic resolution: converts reflection-obtained instance to target type T
-func getTyped[T any](_ *Container, svcType reflect.Type, instance reflect.Value) (T, error) {
-	var zero T
 	// Handle interface types, assignable and convertible types
 	it := instance.Type()
 	// If target type is interface, check implementation relationship
 	if svcType.Kind() == reflect.Interface {
 		// Case 1: Instance type directly implements interface (including pointer types)
 		if it.Implements(svcType) {
-			return instance.Interface().(T), nil
+			return instance, nil
 		}
 		// Case 2: Value type implements interface, but container returns value → try to get address
 		if it.Kind() != reflect.Ptr && reflect.PointerTo(it).Implements(svcType) {
-			var iface any
 			if instance.CanAddr() {
-				iface = instance.Addr().Interface()
-			} else {
-				// Create a new pointer and set value for conversion
-				ptr := reflect.New(it)
-				ptr.Elem().Set(instance)
-				iface = ptr.Interface()
+				return instance.Addr(), nil
 			}
-			return iface.(T), nil
+			// Create a new pointer and set value for conversion
+			ptr := reflect.New(it)
+			ptr.Elem().Set(instance)
+			return ptr, nil
 		}
-		return zero, fmt.Errorf("[%w] instance %s cannot be converted to target interface type %s", ErrTypeConvertFailed, it, svcType)
+		return reflect.Value{}, fmt.Errorf("[%w] instance %s cannot be converted to target interface type %s", ErrTypeConvertFailed, it, svcType)
 	}
 
-	// Target is not interface: check if directly assignable or convertible
+	// Target is not interface (pointer, func, map, ... types): check if directly assignable or convertible
 	if it.AssignableTo(svcType) {
-		return instance.Interface().(T), nil
+		return instance, nil
 	}
 	if it.ConvertibleTo(svcType) {
-		conv := instance.Convert(svcType)
-		return conv.Interface().(T), nil
+		if strict {
+			return reflect.Value{}, fmt.Errorf("[%w] instance %s is only convertible to target type %s (strict types)", ErrTypeConvertFailed, it, svcType)
+		}
+		return instance.Convert(svcType), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("[%w] instance %s cannot be converted to target type %s", ErrTypeConvertFailed, it, svcType)
+}
+
+// implementsInterface Whether values of type t (or a pointer to t) satisfy interface ifaceType
+func implementsInterface(t, ifaceType reflect.Type) bool {
+	if t.Implements(ifaceType) {
+		return true
+	}
+	return t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(ifaceType)
+}
+
+// collectImplementations Collects every registered service whose type implements the slice's interface element type:
+// default services (sorted by type name, Scoped ones skipped on the root container), additional implementations, then named
+// instances (sorted by name)
+func (c *Container) collectImplementations(sliceType reflect.Type, track *resolveTrack) (reflect.Value, error) {
+	elemType := sliceType.Elem()
+	strict := c.isStrict()
+
+	c.mu.RLock()
+	svcTypes := make([]reflect.Type, 0)
+	for t, def := range c.services {
+		// Scoped constructors cannot be built on the root container
+		if implementsInterface(t, elemType) && !scopedOnly(def) {
+			svcTypes = append(svcTypes, t)
+		}
+	}
+	names := make([]string, 0)
+	for name, namedMap := range c.namedServices {
+		for t, def := range namedMap {
+			if def.isInstance && implementsInterface(t, elemType) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(svcTypes, func(i, j int) bool { return svcTypes[i].String() < svcTypes[j].String() })
+	sort.Strings(names)
+
+	results := reflect.MakeSlice(sliceType, 0, len(svcTypes)+len(names))
+	for _, t := range svcTypes {
+		inst, err := c.resolve(t, track)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("failed to resolve implementation %s: %w", t, err)
+		}
+		conv, err := convertInstance(elemType, inst, strict)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		results = reflect.Append(results, conv)
+	}
+
+	// Additional implementations (RegisterImplementation)
+	impls, err := c.implementationValues(elemType, track)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	results = reflect.Append(results, impls...)
+
+	for _, name := range names {
+		c.mu.RLock()
+		namedMap := c.namedServices[name]
+		instances := make([]reflect.Value, 0, len(namedMap))
+		for t, def := range namedMap {
+			if def.isInstance && implementsInterface(t, elemType) {
+				instances = append(instances, def.instance)
+			}
+		}
+		c.mu.RUnlock()
+		for _, inst := range instances {
+			conv, err := convertInstance(elemType, inst, strict)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			results = reflect.Append(results, conv)
+		}
 	}
+	return c.emptySlice(results)
+}
 
-	return zero, fmt.Errorf("[%w] instance %s cannot be converted to target type %s", ErrTypeConvertFailed, it, svcType)
+// SetMustHandler Routes failures of Must* calls (methods of the container and its scopes, MustGet/ScopeMustGet and
+// friends) to handler instead of panicking, e.g. to log and os.Exit. The error is prefixed with the failed operation and
+// still matches the underlying sentinel with errors.Is. If handler returns, the Must* call returns zero values.
+// nil restores the default panic
+func (c *Container) SetMustHandler(handler func(err error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mustHandler = handler
+}
+
+// mustFail Reports a failed Must* call to the SetMustHandler handler, otherwise panics (prefix + err, or err itself
+// when prefix is empty)
+func (c *Container) mustFail(prefix string, err error) {
+	c.mu.RLock()
+	handler := c.mustHandler
+	c.mu.RUnlock()
+	if handler == nil {
+		if prefix == "" {
+			panic(err)
+		}
+		panic(fmt.Sprintf("%s %v", prefix, err))
+	}
+	if prefix != "" {
+		err = fmt.Errorf("%s %w", prefix, err)
+	}
+	handler(err)
 }
 
 // MustRegister ---------------------- Convenient Must series methods (panic on error, preferred for 90% scenarios) ----------------------
 // MustRegister Convenient basic registration: panics directly on error
 func (c *Container) MustRegister(ctor any, scope LifetimeScope) {
 	if err := c.Register(ctor, scope); err != nil {
-		panic(fmt.Sprintf("[DI Registration Failed] %v", err))
+		c.mustFail("[DI Registration Failed]", err)
+	}
+}
+
+// MustRegisterNamed Convenient named constructor registration: panics directly on error
+func (c *Container) MustRegisterNamed(name string, ctor any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterNamed(name, ctor, scope, opts...); err != nil {
+		c.mustFail("[DI Named Registration Failed]", err)
+	}
+}
+
+// MustRegisterWithInit Convenient method injection registration: panics directly on error
+func (c *Container) MustRegisterWithInit(ctor any, initMethodName string, scope LifetimeScope) {
+	if err := c.RegisterWithInit(ctor, initMethodName, scope); err != nil {
+		c.mustFail("[DI Registration Failed]", err)
+	}
+}
+
+// MustRegisterMulti Convenient multi-result registration: panics directly on error
+func (c *Container) MustRegisterMulti(ctor any, scope LifetimeScope) {
+	if err := c.RegisterMulti(ctor, scope); err != nil {
+		c.mustFail("[DI Registration Failed]", err)
+	}
+}
+
+// MustRegisterDynamic Convenient dynamic-lifetime registration: panics directly on error
+func (c *Container) MustRegisterDynamic(ctor any, lifetimeFn func() LifetimeScope) {
+	if err := c.RegisterDynamic(ctor, lifetimeFn); err != nil {
+		c.mustFail("[DI Registration Failed]", err)
+	}
+}
+
+// MustRegisterWithRetry Convenient retrying registration: panics directly on error
+func (c *Container) MustRegisterWithRetry(ctor any, scope LifetimeScope, attempts int, backoff time.Duration) {
+	if err := c.RegisterWithRetry(ctor, scope, attempts, backoff); err != nil {
+		c.mustFail("[DI Registration Failed]", err)
+	}
+}
+
+// MustProvideAll Convenient batch registration: panics directly on error
+func (c *Container) MustProvideAll(scope LifetimeScope, ctors ...any) {
+	if err := c.ProvideAll(scope, ctors...); err != nil {
+		c.mustFail("[DI Batch Registration Failed]", err)
+	}
+}
+
+// MustInstall Convenient module installation: panics directly on error
+func (c *Container) MustInstall(m Module) {
+	if err := c.Install(m); err != nil {
+		c.mustFail("[DI Module Install Failed]", err)
 	}
 }
 
 // MustRegisterAs Convenient interface registration: panics directly on error
-func (c *Container) MustRegisterAs(ctor any, interfaceType any, scope LifetimeScope) {
-	if err := c.RegisterAs(ctor, interfaceType, scope); err != nil {
-		panic(fmt.Sprintf("[DI Interface Registration Failed] %v", err))
+func (c *Container) MustRegisterAs(ctor any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterAs(ctor, interfaceType, scope, opts...); err != nil {
+		c.mustFail("[DI Interface Registration Failed]", err)
 	}
 }
 
 // MustRegisterInstance Convenient instance registration: panics directly on error
 func (c *Container) MustRegisterInstance(instance any, scope LifetimeScope) {
 	if err := c.RegisterInstance(instance, scope); err != nil {
-		panic(fmt.Sprintf("[DI Instance Registration Failed] %v", err))
+		c.mustFail("[DI Instance Registration Failed]", err)
 	}
 }
 
 // MustRegisterInstanceAs Convenient instance interface registration: panics directly on error
 func (c *Container) MustRegisterInstanceAs(instance any, interfaceType any, scope LifetimeScope) {
 	if err := c.RegisterInstanceAs(instance, interfaceType, scope); err != nil {
-		panic(fmt.Sprintf("[DI Instance Interface Registration Failed] %v", err))
+		c.mustFail("[DI Instance Interface Registration Failed]", err)
+	}
+}
+
+// MustRegisterInstanceWithCleanup Convenient instance registration with cleanup: panics directly on error
+func (c *Container) MustRegisterInstanceWithCleanup(instance any, scope LifetimeScope, cleanup func()) {
+	if err := c.RegisterInstanceWithCleanup(instance, scope, cleanup); err != nil {
+		c.mustFail("[DI Instance Registration Failed]", err)
+	}
+}
+
+// MustRegisterImplementation Convenient additional implementation registration: panics directly on error
+func (c *Container) MustRegisterImplementation(ctor any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterImplementation(ctor, interfaceType, scope, opts...); err != nil {
+		c.mustFail("[DI Implementation Registration Failed]", err)
+	}
+}
+
+// MustRegisterInstanceImplementation Convenient additional implementation instance registration: panics directly on error
+func (c *Container) MustRegisterInstanceImplementation(instance any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterInstanceImplementation(instance, interfaceType, scope, opts...); err != nil {
+		c.mustFail("[DI Implementation Registration Failed]", err)
 	}
 }
 
 // MustRegisterInstanceNamed Convenient named instance registration: panics directly on error
-func (c *Container) MustRegisterInstanceNamed(name string, instance any, scope LifetimeScope) {
-	if err := c.RegisterInstanceNamed(name, instance, scope); err != nil {
-		panic(fmt.Sprintf("[DI Named Instance Registration Failed] %v", err))
+func (c *Container) MustRegisterInstanceNamed(name string, instance any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterInstanceNamed(name, instance, scope, opts...); err != nil {
+		c.mustFail("[DI Named Instance Registration Failed]", err)
 	}
 }
 
 // MustRegisterInstanceAsNamed Convenient named instance interface registration: panics directly on error
-func (c *Container) MustRegisterInstanceAsNamed(name string, instance any, interfaceType any, scope LifetimeScope) {
-	if err := c.RegisterInstanceAsNamed(name, instance, interfaceType, scope); err != nil {
-		panic(fmt.Sprintf("[DI Named Instance Interface Registration Failed] %v", err))
+func (c *Container) MustRegisterInstanceAsNamed(name string, instance any, interfaceType any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterInstanceAsNamed(name, instance, interfaceType, scope, opts...); err != nil {
+		c.mustFail("[DI Named Instance Interface Registration Failed]", err)
+	}
+}
+
+// MustRegisterGroup Convenient group registration: panics directly on error
+func (c *Container) MustRegisterGroup(group string, ctor any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterGroup(group, ctor, scope, opts...); err != nil {
+		c.mustFail("[DI Group Registration Failed]", err)
+	}
+}
+
+// MustRegisterInstanceGroup Convenient group instance registration: panics directly on error
+func (c *Container) MustRegisterInstanceGroup(group string, instance any, scope LifetimeScope, opts ...RegisterOption) {
+	if err := c.RegisterInstanceGroup(group, instance, scope, opts...); err != nil {
+		c.mustFail("[DI Group Instance Registration Failed]", err)
+	}
+}
+
+// MustRegisterProvidersStruct Convenient providers struct registration: panics directly on error
+func (c *Container) MustRegisterProvidersStruct(providers any, scope LifetimeScope) {
+	if err := c.RegisterProvidersStruct(providers, scope); err != nil {
+		c.mustFail("[DI Providers Registration Failed]", err)
 	}
 }
 
 // MustResolve Convenient original resolution: panics directly on error
 func (c *Container) MustResolve(out any) {
 	if err := c.Resolve(out); err != nil {
-		panic(fmt.Sprintf("[DI Resolution Failed] %v", err))
+		c.mustFail("[DI Resolution Failed]", err)
+	}
+}
+
+// MustInvoke Convenient invocation: panics directly on error (parameter resolution or fn's own error)
+func (c *Container) MustInvoke(fn any) {
+	if err := c.Invoke(fn); err != nil {
+		c.mustFail("[DI Invoke Failed]", err)
+	}
+}
+
+// MustPopulate Convenient field injection: panics directly on error
+func (c *Container) MustPopulate(target any) {
+	if err := c.Populate(target); err != nil {
+		c.mustFail("[DI Populate Failed]", err)
+	}
+}
+
+// MustResolveMany Convenient batch resolution: panics directly on error
+func (c *Container) MustResolveMany(outs ...any) {
+	if err := c.ResolveMany(outs...); err != nil {
+		c.mustFail("[DI Resolution Failed]", err)
 	}
 }
 
 // MustResolveNamed Convenient named resolution: panics directly on error
 func (c *Container) MustResolveNamed(name string, out any) {
 	if err := c.ResolveNamed(name, out); err != nil {
-		panic(fmt.Sprintf("[DI Named Resolution Failed] %v", err))
+		c.mustFail("[DI Named Resolution Failed]", err)
 	}
 }
 
 // MustResolveAll Convenient resolve all: panics directly on error
-func (c *Container) MustResolveAll(out any) {
-	if err := c.ResolveAll(out); err != nil {
-		panic(fmt.Sprintf("[DI Resolve All Failed] %v", err))
+func (c *Container) MustResolveAll(out any, opts ...ResolveAllOption) {
+	if err := c.ResolveAll(out, opts...); err != nil {
+		c.mustFail("[DI Resolve All Failed]", err)
+	}
+}
+
+// MustResolveAllUnique Convenient deduplicated resolve all: panics directly on error
+func (c *Container) MustResolveAllUnique(out any) {
+	if err := c.ResolveAllUnique(out); err != nil {
+		c.mustFail("[DI Resolve All Failed]", err)
+	}
+}
+
+// MustResolveAllByTypeName Convenient resolve all by type name: panics directly on error
+func (c *Container) MustResolveAllByTypeName(out any) {
+	if err := c.ResolveAllByTypeName(out); err != nil {
+		c.mustFail("[DI Resolve All By Type Name Failed]", err)
 	}
 }
 
 // MustResolve New: Scope's MustResolve method (consistent format with Container)
 func (s *Scope) MustResolve(out any) {
 	if err := s.Resolve(out); err != nil {
-		panic(fmt.Sprintf("[DI Scope Resolution Failed] %v", err))
+		s.root.mustFail("[DI Scope Resolution Failed]", err)
+	}
+}
+
+// MustResolveNamed Scope version of convenient named resolution: panics directly on error
+func (s *Scope) MustResolveNamed(name string, out any) {
+	if err := s.ResolveNamed(name, out); err != nil {
+		s.root.mustFail("[DI Scope Resolution Failed]", err)
 	}
 }
 
 // MustRegister ---------------------- Global container top-level generic functions (directly call di.Get[T](), di.MustGet[T](), extremely concise) ----------------------
 func MustRegister(ctor any, scope LifetimeScope) { Global.MustRegister(ctor, scope) }
-func MustRegisterAs(ctor any, iface any, scope LifetimeScope) {
-	Global.MustRegisterAs(ctor, iface, scope)
+func MustRegisterAs(ctor any, iface any, scope LifetimeScope, opts ...RegisterOption) {
+	Global.MustRegisterAs(ctor, iface, scope, opts...)
 }
 func MustRegisterInstance(instance any, scope LifetimeScope) {
 	Global.MustRegisterInstance(instance, scope)
@@ -889,11 +4763,30 @@ func MustRegisterInstanceAs(instance any, iface any, scope LifetimeScope) {
 }
 func MustResolve(out any) { Global.MustResolve(out) }
 
+// Provide Registers a constructor on the global container (alias of Global.Register)
+func Provide(ctor any, scope LifetimeScope) error { return Global.Provide(ctor, scope) }
+
+// Invoke Calls fn with parameters resolved from the global container, see Container.Invoke
+func Invoke(fn any) error { return Global.Invoke(fn) }
+
 // Get Generic resolution: directly returns instance with error handling (follows Go conventions)
+// If T is a slice of interface that is not registered itself, all implementations of the interface are collected
 func Get[T any]() (T, error) {
 	var zero T
 	svcType := reflect.TypeOf((*T)(nil)).Elem()
-	instance, err := Global.resolve(svcType, make(map[reflect.Type]bool))
+	// Fast path: pre-registered Singleton instance
+	if instance, ok := Global.instanceFastPath(svcType); ok {
+		return getTyped[T](Global, svcType, instance)
+	}
+	track := newResolveTrack(nil)
+	defer track.release()
+	var instance reflect.Value
+	var err error
+	if svcType.Kind() == reflect.Slice && svcType.Elem().Kind() == reflect.Interface && !Global.isRegistered(svcType) {
+		instance, err = Global.collectImplementations(svcType, track)
+	} else {
+		instance, err = Global.resolve(svcType, track)
+	}
 	if err != nil {
 		return zero, fmt.Errorf("[DI Get Failed] %w", err)
 	}
@@ -904,11 +4797,55 @@ func Get[T any]() (T, error) {
 func MustGet[T any]() T {
 	inst, err := Get[T]()
 	if err != nil {
-		panic(err)
+		Global.mustFail("", err)
+	}
+	return inst
+}
+
+// GetAs Explicit conversion on the global container, see ContainerGetAs
+func GetAs[T, Stored any]() (T, error) {
+	return ContainerGetAs[T, Stored](Global)
+}
+
+// MustGetAs Explicit conversion on the global container, panics on error
+func MustGetAs[T, Stored any]() T {
+	inst, err := GetAs[T, Stored]()
+	if err != nil {
+		Global.mustFail("", err)
 	}
 	return inst
 }
 
+// ContainerGetAs Resolves the service registered as Stored and converts it to T on purpose, e.g. an int config value
+// read as int64 (GetAs[int64, int]). Unlike the implicit ConvertibleTo adaptation of Get, it works under StrictTypes,
+// and numeric conversions that lose information (overflow, truncated fractions) fail with ErrTypeConvertFailed
+func ContainerGetAs[T, Stored any](c *Container) (T, error) {
+	var zero T
+	storedType := reflect.TypeOf((*Stored)(nil)).Elem()
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, err := c.resolve(storedType, track)
+	if err != nil {
+		return zero, fmt.Errorf("[DI Get Failed] %w", err)
+	}
+	if !storedType.ConvertibleTo(targetType) {
+		return zero, fmt.Errorf("[%w] %s cannot be converted to %s", ErrTypeConvertFailed, storedType, targetType)
+	}
+	converted := instance.Convert(targetType)
+	if isNumericKind(storedType.Kind()) && isNumericKind(targetType.Kind()) && !converted.Convert(storedType).Equal(instance) {
+		return zero, fmt.Errorf("[%w] %v does not fit in %s", ErrTypeConvertFailed, instance, targetType)
+	}
+	var result T
+	reflect.ValueOf(&result).Elem().Set(converted)
+	return result, nil
+}
+
+// isNumericKind Whether k is an integer or floating-point kind
+func isNumericKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uintptr) || k == reflect.Float32 || k == reflect.Float64
+}
+
 // GlobalNewScope New: convenient method for creating scope globally
 func GlobalNewScope() *Scope {
 	return Global.NewScope()
@@ -918,7 +4855,12 @@ func GlobalNewScope() *Scope {
 func ScopeGet[T any](s *Scope) (T, error) {
 	var zero T
 	svcType := reflect.TypeOf((*T)(nil)).Elem()
-	instance, err := s.resolve(svcType, make(map[reflect.Type]bool))
+	if instance, ok := s.instanceFastPath(svcType); ok {
+		return getTyped[T](s.root, svcType, instance)
+	}
+	track := newResolveTrack(nil)
+	defer track.release()
+	instance, err := s.resolve(svcType, track)
 	if err != nil {
 		return zero, fmt.Errorf("[DI Scope Get Failed] %w", err)
 	}
@@ -929,16 +4871,142 @@ func ScopeGet[T any](s *Scope) (T, error) {
 func ScopeMustGet[T any](s *Scope) T {
 	inst, err := ScopeGet[T](s)
 	if err != nil {
-		panic(err)
+		s.root.mustFail("", err)
 	}
 	return inst
 }
 
-// Reset Resets container: clears all services and caches (for testing)
+// Invalidate Drops the cached instance of a constructor-backed Singleton so the next resolution constructs it again
+// (e.g. hot-reloaded configuration); OnFirstInit callbacks fire again for the rebuilt instance. Instances already
+// handed out are unaffected. Pre-registered instances and non-Singleton lifetimes return ErrInvalidateNotSupported
+func (c *Container) Invalidate(t reflect.Type) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	serviceDef, exists := c.services[t]
+	if !exists {
+		return fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, t)
+	}
+	if serviceDef.isInstance || (serviceDef.scope != Singleton && serviceDef.lifetimeFn == nil) {
+		return fmt.Errorf("%w, type: %s", ErrInvalidateNotSupported, t)
+	}
+	// Swap in a fresh registration rather than resetting the sync.Once in place, so a construction racing with
+	// Invalidate completes against the old registration
+	c.services[t] = serviceDef.cloneRegistration()
+	return nil
+}
+
+// Invalidate Singleton invalidation on the global container, see ContainerInvalidate
+func Invalidate[T any]() error {
+	return ContainerInvalidate[T](Global)
+}
+
+// ContainerInvalidate Generic version of Container.Invalidate
+func ContainerInvalidate[T any](c *Container) error {
+	return c.Invalidate(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// ResolveFresh Resolves the default registration of out's type by running its constructor (or factory) anew, ignoring
+// and not touching a cached Singleton, e.g. for an independent copy in tests. Dependencies resolve as usual (cached
+// singletons are reused). Pre-registered instances cannot be rebuilt and fail with ErrFreshNotSupported
+func (c *Container) ResolveFresh(out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+	c.mu.RLock()
+	serviceDef, exists := c.services[svcType]
+	c.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, svcType)
+	}
+	if serviceDef.isInstance {
+		return fmt.Errorf("%w, type: %s", ErrFreshNotSupported, svcType)
+	}
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	track.visiting[svcType] = true
+	track.beginBuild(ResolutionInfo{Type: svcType, Lifetime: serviceDef.lifetime(), Name: serviceDef.name})
+	instance, err := serviceDef.construct(c, c.resolveParams, track)
+	if err != nil {
+		return err
+	}
+	if instance, err = c.finishResolve(svcType, instance, false); err != nil {
+		return err
+	}
+	if instance, err = convertInstance(svcType, instance, c.isStrict()); err != nil {
+		return err
+	}
+	outVal.Elem().Set(instance)
+	return nil
+}
+
+// GetFresh Fresh resolution on the global container, see ContainerGetFresh
+func GetFresh[T any]() (T, error) {
+	return ContainerGetFresh[T](Global)
+}
+
+// ContainerGetFresh Generic version of Container.ResolveFresh
+func ContainerGetFresh[T any](c *Container) (T, error) {
+	var result T
+	err := c.ResolveFresh(&result)
+	return result, err
+}
+
+// ClearCaches Drops every cached singleton (default, named, keyed, RegisterImplementation and group registrations) while keeping
+// the registrations, so the next resolution of each rebuilds it; useful between test cases. Instance registrations
+// keep their instance. Unlike Reset nothing is unregistered, unlike Invalidate it covers all types at once
+func (c *Container) ClearCaches() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A registration may be reachable from several maps: clone it once so they keep sharing the fresh one
+	fresh := make(map[*ServiceDef]*ServiceDef)
+	refresh := func(def *ServiceDef) *ServiceDef {
+		if def.isInstance || (def.scope != Singleton && def.lifetimeFn == nil) {
+			return def
+		}
+		if clone, ok := fresh[def]; ok {
+			return clone
+		}
+		clone := def.cloneRegistration()
+		fresh[def] = clone
+		return clone
+	}
+	for t, def := range c.services {
+		c.services[t] = refresh(def)
+	}
+	for _, namedMap := range c.namedServices {
+		for t, def := range namedMap {
+			namedMap[t] = refresh(def)
+		}
+	}
+	for id, def := range c.keyedServices {
+		c.keyedServices[id] = refresh(def)
+	}
+	for _, impls := range c.implementations {
+		for i, def := range impls {
+			impls[i] = refresh(def)
+		}
+	}
+	for _, members := range c.groups {
+		for i, def := range members {
+			members[i] = refresh(def)
+		}
+	}
+}
+
+// Reset Resets container: clears all services (default and named) and caches (for testing)
 func (c *Container) Reset() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.services = make(map[reflect.Type]*ServiceDef)
+	c.namedServices = make(map[string]map[reflect.Type]*ServiceDef)
+	c.keyedServices = make(map[any]*ServiceDef)
+	c.implementations = make(map[reflect.Type][]*ServiceDef)
+	c.groups = make(map[string][]*ServiceDef)
+	c.namedScopes = nil
+	c.invalidateImplementsLocked()
 }
 
 // Reset Replace with 👇 fixed code
@@ -946,7 +5014,10 @@ func (s *Scope) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock() // Correct: use scope's own lock
 	s.scopedInst = make(map[reflect.Type]reflect.Value)
+	s.inherited = nil
+	s.scopedDefs = nil
 }
 
-// GlobalReset Resets global container (for testing)
-func GlobalReset() { Global.Reset() }
+// GlobalReset Replaces the global container with a pristine one (services, named services, options and any
+// accumulated state are all dropped). For tests only: not goroutine-safe against concurrent use of Global.
+func GlobalReset() { Global = NewContainer() }
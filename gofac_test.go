@@ -1,8 +1,14 @@
 package gofac
 
 import (
+	"context"
 	"errors"
+	"io"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -308,6 +314,69 @@ func TestResolveAll(t *testing.T) {
 	}
 }
 
+// TestScopeResolveAll tests that a scope's ResolveAll includes Scoped registrations resolved into the scope
+func TestScopeResolveAll(t *testing.T) {
+	container := NewContainer()
+	calls := 0
+	newScoped := func(value string) func() *TestService {
+		return func() *TestService {
+			calls++
+			return &TestService{Value: value}
+		}
+	}
+	container.MustRegister(newScoped("default"), Scoped)
+	container.MustRegisterInstanceNamed("instance", &TestService{Value: "instance"}, Singleton)
+	container.MustRegisterNamed("first", newScoped("first"), Scoped, WithPriority(-1))
+	container.MustRegisterNamed("transient", newScoped("transient"), Transient)
+
+	// The root container only sees instances
+	var rootResults []*TestService
+	container.MustResolveAll(&rootResults)
+	if len(rootResults) != 1 {
+		t.Fatalf("Expected 1 root result, got %d", len(rootResults))
+	}
+
+	scope := container.NewScope()
+	var results []*TestService
+	if err := scope.ResolveAll(&results); err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+	values := make([]string, len(results))
+	for i, r := range results {
+		values[i] = r.Value
+	}
+	if strings.Join(values, ",") != "first,default,instance" {
+		t.Errorf("Expected priority then registration order, got %v", values)
+	}
+
+	// Scoped instances are shared with the scope and reused by later calls
+	var byType *TestService
+	scope.MustResolve(&byType)
+	var again []*TestService
+	_ = scope.ResolveAll(&again)
+	if byType != results[1] || again[0] != results[0] || calls != 2 {
+		t.Errorf("Expected scoped instances to be reused within the scope (calls=%d)", calls)
+	}
+
+	// Nested scopes reuse the parent's instances, sibling scopes build their own
+	var nested, sibling []*TestService
+	_ = scope.NewScope().ResolveAll(&nested)
+	_ = container.NewScope().ResolveAll(&sibling)
+	if nested[0] != results[0] || sibling[0] == results[0] {
+		t.Error("Expected nested scope to inherit and sibling scope to own scoped instances")
+	}
+
+	// Overrides replace the default registration
+	override := &TestService{Value: "override"}
+	overridden := container.NewScope()
+	_ = overridden.Override(override)
+	var withOverride []*TestService
+	_ = overridden.ResolveAll(&withOverride)
+	if withOverride[1] != override {
+		t.Error("Expected the scope override in place of the default registration")
+	}
+}
+
 // TestMustRegister tests Must* methods panic behavior
 func TestMustRegister(t *testing.T) {
 	container := NewContainer()
@@ -1743,10 +1812,21 @@ func TestRegisterWithZeroReturnValues(t *testing.T) {
 
 // TestResolveNamedWithNonInstanceService tests ResolveNamed when service is not an instance
 func TestResolveNamedWithNonInstanceService(t *testing.T) {
-	// This test is to cover the case where named services don't support constructor registration
-	// Currently, the code only supports instance registration for named services
-	// So this test is skipped as it's not a valid use case
-	t.Skip("Named services only support instance registration")
+	container := NewContainer()
+	container.MustRegisterNamed("primary", NewTestService, Singleton)
+	container.MustRegisterNamed("scoped", NewTestService, Scoped)
+
+	var first, second *TestService
+	container.MustResolveNamed("primary", &first)
+	container.MustResolveNamed("primary", &second)
+	if first != second {
+		t.Error("Named singleton constructor should be cached")
+	}
+
+	err := container.ResolveNamed("scoped", &first)
+	if err != ErrScopedOnRootContainer {
+		t.Errorf("Expected ErrScopedOnRootContainer, got %v", err)
+	}
 }
 
 // TestScopeResolveWithRegisteredSliceType tests scope resolution with registered slice type
@@ -1856,3 +1936,4149 @@ func TestRegisterAsWithIncompatibleConcreteType(t *testing.T) {
 		t.Error("Expected error for incompatible concrete type")
 	}
 }
+
+// TestResolveValueFromRegisteredPointer tests resolving a value parameter when only the pointer is registered
+func TestResolveValueFromRegisteredPointer(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+
+	type Consumer struct {
+		Dep TestDependency
+	}
+	container.MustRegister(func(dep TestDependency) *Consumer {
+		return &Consumer{Dep: dep}
+	}, Transient)
+
+	// Disabled by default
+	var result *Consumer
+	if err := container.Resolve(&result); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("Expected ErrServiceNotRegistered without auto-deref, got %v", err)
+	}
+
+	container.SetAutoDeref(true)
+	if err := container.Resolve(&result); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if result.Dep.Name != "dependency" {
+		t.Errorf("Expected 'dependency', got '%s'", result.Dep.Name)
+	}
+
+	scope := container.NewScope()
+	var scoped *Consumer
+	if err := scope.Resolve(&scoped); err != nil {
+		t.Fatalf("Scope resolve failed: %v", err)
+	}
+	if scoped.Dep.Name != "dependency" {
+		t.Errorf("Expected 'dependency', got '%s'", scoped.Dep.Name)
+	}
+}
+
+// TestResolvePointerFromRegisteredValue tests resolving a pointer parameter when only the value is registered
+func TestResolvePointerFromRegisteredValue(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func() TestDependency {
+		return TestDependency{Name: "value"}
+	}, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	container.SetAutoDeref(true)
+
+	var result *TestServiceWithDep
+	if err := container.Resolve(&result); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if result.Dep == nil || result.Dep.Name != "value" {
+		t.Errorf("Expected dependency with name 'value', got %+v", result.Dep)
+	}
+
+	// Interfaces have no pointer counterpart
+	var iface ITestInterface
+	err := container.Resolve(&iface)
+	if !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+// TestScopeClone tests that a cloned scope shares resolved instances but diverges afterwards
+func TestScopeClone(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Scoped)
+	container.MustRegister(NewTestDependency, Scoped)
+
+	parent := container.NewScope()
+	parentSvc := ScopeMustGet[*TestService](parent)
+
+	clone := parent.Clone()
+	if clone.root != parent.root {
+		t.Error("Clone should share the root container")
+	}
+	if ScopeMustGet[*TestService](clone) != parentSvc {
+		t.Error("Clone should reuse the parent's resolved scoped instance")
+	}
+	if !clone.inherited[reflect.TypeOf(parentSvc)] {
+		t.Error("Instance copied from parent should be marked as inherited")
+	}
+
+	// New scoped instances in the clone must not leak into the parent
+	cloneDep := ScopeMustGet[*TestDependency](clone)
+	parent.mu.RLock()
+	_, leaked := parent.scopedInst[reflect.TypeOf(cloneDep)]
+	parent.mu.RUnlock()
+	if leaked {
+		t.Error("Instance created in clone should not be visible in parent")
+	}
+	if ScopeMustGet[*TestDependency](parent) == cloneDep {
+		t.Error("Parent should create its own scoped instance after fork")
+	}
+	if clone.inherited[reflect.TypeOf(cloneDep)] {
+		t.Error("Instance created by clone should not be marked as inherited")
+	}
+}
+
+// TestResolveDetailed tests cache-hit reporting on Container and Scope
+func TestResolveDetailed(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegister(NewTestDependency, Transient)
+	container.MustRegister(NewTestImpl, Scoped)
+
+	var svc *TestService
+	fromCache, err := container.ResolveDetailed(&svc)
+	if err != nil {
+		t.Fatalf("ResolveDetailed failed: %v", err)
+	}
+	if fromCache {
+		t.Error("First singleton resolution should be freshly constructed")
+	}
+	if fromCache, _ = container.ResolveDetailed(&svc); !fromCache {
+		t.Error("Second singleton resolution should hit the cache")
+	}
+
+	var dep *TestDependency
+	if fromCache, _ = container.ResolveDetailed(&dep); fromCache {
+		t.Error("Transient resolution should never hit the cache")
+	}
+
+	scope := container.NewScope()
+	var impl *TestImpl
+	if fromCache, _ = scope.ResolveDetailed(&impl); fromCache {
+		t.Error("First scoped resolution should be freshly constructed")
+	}
+	if fromCache, _ = scope.ResolveDetailed(&impl); !fromCache {
+		t.Error("Second scoped resolution should hit the scope cache")
+	}
+
+	if _, err = container.ResolveDetailed(nil); err != ErrInvalidOutPtr {
+		t.Errorf("Expected ErrInvalidOutPtr, got %v", err)
+	}
+}
+
+// Two-phase init test types
+type TwoPhaseService struct {
+	Dep     *TestDependency
+	Service *TestService
+}
+
+func NewTwoPhaseService() *TwoPhaseService {
+	return &TwoPhaseService{}
+}
+
+func (s *TwoPhaseService) Init(dep *TestDependency, svc *TestService) {
+	s.Dep = dep
+	s.Service = svc
+}
+
+func (s *TwoPhaseService) InitWithError(dep *TestDependency) error {
+	return errors.New("init failed")
+}
+
+func (s *TwoPhaseService) BadInit() (int, error) {
+	return 0, nil
+}
+
+// TestRegisterWithInit tests method injection after construction
+func TestRegisterWithInit(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestService, Transient)
+	container.MustRegisterWithInit(NewTwoPhaseService, "Init", Singleton)
+
+	var svc *TwoPhaseService
+	container.MustResolve(&svc)
+	if svc.Dep == nil || svc.Dep.Name != "dependency" {
+		t.Errorf("Init should inject dependency, got %+v", svc.Dep)
+	}
+	if svc.Service == nil || svc.Service.Value != "test" {
+		t.Errorf("Init should inject service, got %+v", svc.Service)
+	}
+
+	// Scope path
+	scope := container.NewScope()
+	if ScopeMustGet[*TwoPhaseService](scope) != svc {
+		t.Error("Singleton with init should be shared with scope")
+	}
+}
+
+// TestRegisterWithInitValidation tests init method validation at registration and init errors at resolution
+func TestRegisterWithInitValidation(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterWithInit(NewTwoPhaseService, "Missing", Singleton)
+	if !errors.Is(err, ErrInvalidInitMethod) {
+		t.Errorf("Expected ErrInvalidInitMethod for missing method, got %v", err)
+	}
+	err = container.RegisterWithInit(NewTwoPhaseService, "BadInit", Singleton)
+	if !errors.Is(err, ErrInvalidInitMethod) {
+		t.Errorf("Expected ErrInvalidInitMethod for bad return types, got %v", err)
+	}
+	if err = container.RegisterWithInit("not a func", "Init", Singleton); err != ErrNotFunc {
+		t.Errorf("Expected ErrNotFunc, got %v", err)
+	}
+
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegisterWithInit(NewTwoPhaseService, "InitWithError", Transient)
+	var svc *TwoPhaseService
+	err = container.Resolve(&svc)
+	if !errors.Is(err, ErrCreateInstanceFailed) {
+		t.Errorf("Expected ErrCreateInstanceFailed from failing init, got %v", err)
+	}
+}
+
+// TestRegisterInstanceNotAssignable tests that instance type mismatches wrap ErrInstanceNotAssignable
+func TestRegisterInstanceNotAssignable(t *testing.T) {
+	container := NewContainer()
+
+	// Does not implement interface
+	err := container.RegisterInstanceAs(&TestService{}, (*ITestInterface)(nil), Singleton)
+	if !errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Expected ErrInstanceNotAssignable for interface mismatch, got %v", err)
+	}
+
+	// Incompatible concrete type
+	err = container.RegisterInstanceAs(&TestService{}, (*TestDependency)(nil), Singleton)
+	if !errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Expected ErrInstanceNotAssignable for concrete mismatch, got %v", err)
+	}
+
+	// Named variants
+	err = container.RegisterInstanceAsNamed("svc", &TestService{}, (*ITestInterface)(nil), Singleton)
+	if !errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Expected ErrInstanceNotAssignable for named interface mismatch, got %v", err)
+	}
+	err = container.RegisterInstanceAsNamed("svc", &TestService{}, (*TestDependency)(nil), Singleton)
+	if !errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Expected ErrInstanceNotAssignable for named concrete mismatch, got %v", err)
+	}
+
+	// Constructor registration failures are distinguishable
+	err = container.RegisterAs(NewTestService, (*ITestInterface)(nil), Singleton)
+	if err == nil || errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Constructor registration failure should not wrap ErrInstanceNotAssignable, got %v", err)
+	}
+}
+
+// Multiple implementations for interface collection tests
+type TestImplB struct{}
+
+func (t TestImplB) GetValue() string {
+	return "implB"
+}
+
+// TestGetSliceOfInterface tests collecting all interface implementations through Get
+func TestGetSliceOfInterface(t *testing.T) {
+	GlobalReset()
+	defer GlobalReset()
+
+	MustRegister(NewTestImpl, Singleton)
+	MustRegister(func() TestImplB { return TestImplB{} }, Transient)
+	MustRegister(NewTestService, Singleton) // does not implement ITestInterface
+
+	impls, err := Get[[]ITestInterface]()
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(impls) != 2 {
+		t.Fatalf("Expected 2 implementations, got %d", len(impls))
+	}
+	values := map[string]bool{}
+	for _, impl := range impls {
+		values[impl.GetValue()] = true
+	}
+	if !values["impl"] || !values["implB"] {
+		t.Errorf("Expected both implementations, got %v", values)
+	}
+
+	// Registered slice type takes precedence over collection
+	MustRegisterInstance([]ITestInterface{&TestImpl{Value: "registered"}}, Singleton)
+	impls = MustGet[[]ITestInterface]()
+	if len(impls) != 1 || impls[0].GetValue() != "registered" {
+		t.Errorf("Expected registered slice, got %v", impls)
+	}
+}
+
+// TestCollectImplementationsWithNamed tests that named instances and scoped services are handled during collection
+func TestCollectImplementationsWithNamed(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceAs(&TestImpl{Value: "default"}, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstanceNamed("b", &TestImpl{Value: "named-b"}, Singleton)
+	container.MustRegisterInstanceNamed("a", &TestImpl{Value: "named-a"}, Singleton)
+	container.MustRegister(func() TestImplB { return TestImplB{} }, Scoped)
+
+	sliceType := reflect.TypeOf([]ITestInterface(nil))
+	result, err := container.collectImplementations(sliceType, newResolveTrack(nil))
+	if err != nil {
+		t.Fatalf("collectImplementations failed: %v", err)
+	}
+	impls := result.Interface().([]ITestInterface)
+	if len(impls) != 3 {
+		t.Fatalf("Expected 3 implementations (scoped skipped), got %d", len(impls))
+	}
+	if impls[0].GetValue() != "default" || impls[1].GetValue() != "named-a" || impls[2].GetValue() != "named-b" {
+		t.Errorf("Unexpected order: %s, %s, %s", impls[0].GetValue(), impls[1].GetValue(), impls[2].GetValue())
+	}
+}
+
+// TestMergeContainers tests merging registrations from another container
+func TestMergeContainers(t *testing.T) {
+	moduleA := NewContainer()
+	moduleA.MustRegister(NewTestDependency, Singleton)
+	moduleA.MustRegisterInstanceNamed("a", &TestService{Value: "a"}, Singleton)
+
+	moduleB := NewContainer()
+	moduleB.MustRegister(NewTestServiceWithDep, Singleton)
+	moduleB.MustRegisterInstanceNamed("b", &TestService{Value: "b"}, Singleton)
+
+	// Build singleton before merge: cache must be reset afterwards
+	var before *TestDependency
+	moduleA.MustResolve(&before)
+
+	if err := moduleA.Merge(moduleB); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	var svc *TestServiceWithDep
+	if err := moduleA.Resolve(&svc); err != nil {
+		t.Fatalf("Resolve after merge failed: %v", err)
+	}
+	if svc.Dep == before {
+		t.Error("Singleton cache should be reset on merge")
+	}
+
+	var all []*TestService
+	moduleA.MustResolveAll(&all)
+	if len(all) != 2 {
+		t.Errorf("Expected 2 named services after merge, got %d", len(all))
+	}
+}
+
+// TestMergeConflict tests duplicate detection and overwrite on merge
+func TestMergeConflict(t *testing.T) {
+	target := NewContainer()
+	target.MustRegisterInstance(&TestService{Value: "first"}, Singleton)
+	target.MustRegisterInstanceNamed("n", &TestDependency{Name: "first"}, Singleton)
+
+	other := NewContainer()
+	other.MustRegisterInstance(&TestService{Value: "second"}, Singleton)
+	other.MustRegister(NewTestImpl, Singleton)
+
+	err := target.Merge(other)
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Fatalf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	// Failed merge leaves target untouched
+	var impl *TestImpl
+	if err = target.Resolve(&impl); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Failed merge should not copy registrations, got %v", err)
+	}
+
+	namedOther := NewContainer()
+	namedOther.MustRegisterInstanceNamed("n", &TestDependency{Name: "second"}, Singleton)
+	if err = target.Merge(namedOther); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate for named conflict, got %v", err)
+	}
+
+	if err = target.Merge(other, WithOverwrite()); err != nil {
+		t.Fatalf("Merge with overwrite failed: %v", err)
+	}
+	var svc *TestService
+	target.MustResolve(&svc)
+	if svc.Value != "second" {
+		t.Errorf("Expected later registration to win, got '%s'", svc.Value)
+	}
+	target.MustResolve(&impl)
+}
+
+// TestMergeOrderAndConcurrency tests that merged registrations follow the receiver's own, and that containers merging
+// into each other concurrently do not deadlock
+func TestMergeOrderAndConcurrency(t *testing.T) {
+	target := NewContainer()
+	target.MustRegisterInstanceNamed("t1", &TestService{Value: "t1"}, Singleton)
+	target.MustRegisterInstanceNamed("t2", &TestService{Value: "t2"}, Singleton)
+	other := NewContainer()
+	other.MustRegisterInstanceNamed("o1", &TestService{Value: "o1"}, Singleton)
+	other.MustRegisterInstanceNamed("o2", &TestService{Value: "o2"}, Singleton)
+	if err := target.Merge(other); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	target.MustRegisterInstanceNamed("t3", &TestService{Value: "t3"}, Singleton)
+
+	var all []*TestService
+	target.MustResolveAll(&all)
+	got := make([]string, len(all))
+	for i, svc := range all {
+		got[i] = svc.Value
+	}
+	if want := []string{"t1", "t2", "o1", "o2", "t3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected merged registrations after the receiver's, got %v", got)
+	}
+
+	for i := 0; i < 50; i++ {
+		a, b := NewContainer(), NewContainer()
+		a.MustRegisterInstanceNamed("a", &TestService{Value: "a"}, Singleton)
+		b.MustRegisterInstanceNamed("b", &TestService{Value: "b"}, Singleton)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); _ = a.Merge(b) }()
+		go func() { defer wg.Done(); _ = b.Merge(a) }()
+		wg.Wait()
+	}
+}
+
+// TestInstallModule tests installing a module of interdependent registrations
+func TestInstallModule(t *testing.T) {
+	module := Module{
+		func(c *Container) error { return c.Register(NewTestServiceWithDep, Transient) },
+		func(c *Container) error { return c.Register(NewTestDependency, Singleton) },
+		func(c *Container) error { return c.RegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton) },
+	}
+
+	container := NewContainer()
+	if err := container.Install(module); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	var svc *TestServiceWithDep
+	container.MustResolve(&svc)
+	if svc.Dep == nil || svc.Dep.Name != "dependency" {
+		t.Errorf("Expected dependency to be injected, got %+v", svc.Dep)
+	}
+	var iface ITestInterface
+	container.MustResolve(&iface)
+
+	// Installing again aggregates all duplicate errors
+	err := container.Install(module)
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 3 {
+		t.Errorf("Expected 3 aggregated errors, got %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected MustInstall to panic")
+		}
+	}()
+	container.MustInstall(module)
+}
+
+// TestRegisterSelfDependentConstructor tests that direct self-referencing constructors are rejected at registration
+func TestRegisterSelfDependentConstructor(t *testing.T) {
+	container := NewContainer()
+
+	err := container.Register(func(s *TestService) *TestService { return s }, Singleton)
+	if !errors.Is(err, ErrResolveCircularDependency) {
+		t.Errorf("Expected ErrResolveCircularDependency, got %v", err)
+	}
+
+	// Parameter equal to the registered interface type
+	err = container.RegisterAs(func(i ITestInterface) *TestImpl { return &TestImpl{} }, (*ITestInterface)(nil), Singleton)
+	if !errors.Is(err, ErrResolveCircularDependency) {
+		t.Errorf("Expected ErrResolveCircularDependency for interface self-reference, got %v", err)
+	}
+
+	// Rejected registration must not be stored
+	if err = container.Register(NewTestService, Singleton); err != nil {
+		t.Errorf("Register after rejected self-dependency failed: %v", err)
+	}
+}
+
+// TestRegisterFactory tests factory registration with container lookups, caching and error propagation
+func TestRegisterFactory(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+
+	calls := 0
+	err := ContainerRegisterFactory[*TestServiceWithDep](container, func(c *Container) (*TestServiceWithDep, error) {
+		calls++
+		var dep *TestDependency
+		if err := c.Resolve(&dep); err != nil {
+			return nil, err
+		}
+		return &TestServiceWithDep{Dep: dep}, nil
+	}, Singleton)
+	if err != nil {
+		t.Fatalf("ContainerRegisterFactory failed: %v", err)
+	}
+
+	var first, second *TestServiceWithDep
+	container.MustResolve(&first)
+	container.MustResolve(&second)
+	if first != second || calls != 1 {
+		t.Errorf("Singleton factory should be called once, got %d calls", calls)
+	}
+	if first.Dep == nil || first.Dep.Name != "dependency" {
+		t.Errorf("Factory should resolve dependency, got %+v", first.Dep)
+	}
+
+	// Interface-typed factory in a scope
+	ContainerRegisterFactory[ITestInterface](container, func(c *Container) (ITestInterface, error) {
+		return &TestImpl{Value: "factory"}, nil
+	}, Scoped)
+	scope := container.NewScope()
+	if ScopeMustGet[ITestInterface](scope).GetValue() != "factory" {
+		t.Error("Expected factory-built interface instance")
+	}
+
+	// Duplicate
+	err = ContainerRegisterFactory[ITestInterface](container, func(c *Container) (ITestInterface, error) { return nil, nil }, Scoped)
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+
+	// Error propagation
+	factoryErr := errors.New("boom")
+	ContainerRegisterFactory[*TestService](container, func(c *Container) (*TestService, error) {
+		return nil, factoryErr
+	}, Transient)
+	var svc *TestService
+	err = container.Resolve(&svc)
+	if !errors.Is(err, ErrCreateInstanceFailed) || !errors.Is(err, factoryErr) {
+		t.Errorf("Expected wrapped factory error, got %v", err)
+	}
+}
+
+// TestGlobalRegisterFactory tests factory registration on the global container
+func TestGlobalRegisterFactory(t *testing.T) {
+	GlobalReset()
+	defer GlobalReset()
+
+	if err := RegisterFactory[*TestService](func(c *Container) (*TestService, error) {
+		return &TestService{Value: "global-factory"}, nil
+	}, Transient); err != nil {
+		t.Fatalf("RegisterFactory failed: %v", err)
+	}
+	if MustGet[*TestService]().Value != "global-factory" {
+		t.Error("Expected factory-built instance from global container")
+	}
+}
+
+// Name injection test types
+type LabeledService struct {
+	Label string
+	Dep   *TestDependency
+}
+
+func NewLabeledService(dep *TestDependency, name ServiceName) *LabeledService {
+	return &LabeledService{Label: string(name), Dep: dep}
+}
+
+// TestServiceNameInjection tests that constructors receive their registration name
+func TestServiceNameInjection(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegisterNamed("metrics", NewLabeledService, Transient)
+	container.MustRegisterNamed("audit", NewLabeledService, Transient)
+	container.MustRegister(NewLabeledService, Transient)
+
+	var metrics, audit, unnamed *LabeledService
+	container.MustResolveNamed("metrics", &metrics)
+	container.MustResolveNamed("audit", &audit)
+	container.MustResolve(&unnamed)
+
+	if metrics.Label != "metrics" || audit.Label != "audit" {
+		t.Errorf("Expected names to be injected, got '%s' and '%s'", metrics.Label, audit.Label)
+	}
+	if unnamed.Label != "" {
+		t.Errorf("Default registration should receive empty name, got '%s'", unnamed.Label)
+	}
+	if metrics.Dep == nil {
+		t.Error("Other parameters should still be resolved")
+	}
+
+	if err := container.RegisterNamed("", NewLabeledService, Transient); err == nil {
+		t.Error("Expected error for empty name")
+	}
+	if err := container.RegisterNamed("metrics", NewLabeledService, Transient); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+}
+
+// TestResolveAllPriority tests that ResolveAll orders by priority independent of registration order
+func TestResolveAllPriority(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceNamed("last", &TestService{Value: "last"}, Singleton, WithPriority(100))
+	container.MustRegisterInstanceNamed("tie-1", &TestService{Value: "tie-1"}, Singleton)
+	container.MustRegisterInstanceNamed("first", &TestService{Value: "first"}, Singleton, WithPriority(-10))
+	container.MustRegisterInstanceNamed("tie-2", &TestService{Value: "tie-2"}, Singleton)
+	container.MustRegisterInstance(&TestService{Value: "default"}, Singleton)
+
+	var all []*TestService
+	container.MustResolveAll(&all)
+
+	expected := []string{"first", "tie-1", "tie-2", "default", "last"}
+	if len(all) != len(expected) {
+		t.Fatalf("Expected %d services, got %d", len(expected), len(all))
+	}
+	for i, svc := range all {
+		if svc.Value != expected[i] {
+			t.Errorf("Position %d: expected '%s', got '%s'", i, expected[i], svc.Value)
+		}
+	}
+}
+
+// TestResolveAllByTypeName tests collecting implementations keyed by concrete type name
+func TestResolveAllByTypeName(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestImpl, Singleton)
+	container.MustRegister(func() TestImplB { return TestImplB{} }, Transient)
+	container.MustRegisterInstanceNamed("extra", &TestImpl{Value: "named"}, Singleton)
+
+	var byType map[string]ITestInterface
+	if err := container.ResolveAllByTypeName(&byType); err != nil {
+		t.Fatalf("ResolveAllByTypeName failed: %v", err)
+	}
+	if len(byType) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %v", len(byType), byType)
+	}
+	if byType["TestImpl"].GetValue() != "impl" {
+		t.Errorf("Expected default TestImpl to win, got '%s'", byType["TestImpl"].GetValue())
+	}
+	if byType["TestImplB"].GetValue() != "implB" {
+		t.Errorf("Expected TestImplB entry, got %v", byType["TestImplB"])
+	}
+
+	var invalid map[string]*TestImpl
+	if err := container.ResolveAllByTypeName(&invalid); err == nil {
+		t.Error("Expected error for non-interface map value type")
+	}
+	if err := container.ResolveAllByTypeName(nil); err != ErrInvalidOutPtr {
+		t.Errorf("Expected ErrInvalidOutPtr, got %v", err)
+	}
+}
+
+// TestResolveAllByTypeNameCollision tests full-path keys for distinct types sharing a simple name
+func TestResolveAllByTypeNameCollision(t *testing.T) {
+	type TestImpl struct{ ITestInterface } // distinct type with the same simple name as the package-level TestImpl
+
+	container := NewContainer()
+	container.MustRegister(NewTestImpl, Singleton)
+	container.MustRegisterInstance(&TestImpl{ITestInterface: &TestImplB{}}, Singleton)
+
+	var byType map[string]ITestInterface
+	container.MustResolveAllByTypeName(&byType)
+	if len(byType) == 0 {
+		t.Fatal("Expected entries for colliding types")
+	}
+	fullPath := reflect.TypeOf(TestImpl{}).PkgPath() + ".TestImpl"
+	for key := range byType {
+		if key != fullPath {
+			t.Errorf("Colliding names should use full path '%s', got key '%s'", fullPath, key)
+		}
+	}
+}
+
+// TestRegisterAsWithInterfaceReturnType tests registering constructors that already return the target interface
+func TestRegisterAsWithInterfaceReturnType(t *testing.T) {
+	container := NewContainer()
+
+	err := container.RegisterAs(func() io.Reader { return strings.NewReader("data") }, (*io.Reader)(nil), Singleton)
+	if err != nil {
+		t.Fatalf("RegisterAs with interface return type failed: %v", err)
+	}
+	var reader io.Reader
+	container.MustResolve(&reader)
+	data, _ := io.ReadAll(reader)
+	if string(data) != "data" {
+		t.Errorf("Expected 'data', got '%s'", data)
+	}
+
+	// Wider return interface registered as the embedded interface
+	err = container.RegisterAs(func() io.ReadCloser { return io.NopCloser(strings.NewReader("")) }, (*io.Closer)(nil), Singleton)
+	if err != nil {
+		t.Errorf("RegisterAs with embedding interface return type failed: %v", err)
+	}
+
+	// Return interface that does not satisfy the target
+	err = container.RegisterAs(func() io.Reader { return nil }, (*io.Writer)(nil), Singleton)
+	if err == nil {
+		t.Error("Expected error for non-implementing interface return type")
+	}
+
+	// Interface return with concrete target stays rejected
+	err = container.RegisterAs(func() ITestInterface { return &TestImpl{} }, (*TestImpl)(nil), Singleton)
+	if !errors.Is(err, ErrNotConcreteType) {
+		t.Errorf("Expected ErrNotConcreteType, got %v", err)
+	}
+}
+
+// TestResolvePointerToValueSingleton tests that a value singleton hands out a stable address for pointer requests
+func TestResolvePointerToValueSingleton(t *testing.T) {
+	type Config struct {
+		Port int
+	}
+
+	container := NewContainer()
+	container.MustRegister(func() Config { return Config{Port: 8080} }, Singleton)
+
+	var first, second *Config
+	if err := container.Resolve(&first); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	container.MustResolve(&second)
+	if first != second {
+		t.Error("Pointer to value singleton should be identical across resolves")
+	}
+	if first.Port != 8080 {
+		t.Errorf("Expected port 8080, got %d", first.Port)
+	}
+
+	scope := container.NewScope()
+	if ScopeMustGet[*Config](scope) != first {
+		t.Error("Scope should hand out the same address")
+	}
+
+	// Value instance registration
+	container.MustRegisterInstance(TestDependency{Name: "instance"}, Singleton)
+	var dep1, dep2 *TestDependency
+	container.MustResolve(&dep1)
+	container.MustResolve(&dep2)
+	if dep1 != dep2 || dep1.Name != "instance" {
+		t.Error("Pointer to value instance should be stable")
+	}
+
+	// Transient values are not adapted without auto-deref
+	container.MustRegister(func() TestService { return TestService{} }, Transient)
+	var svc *TestService
+	if err := container.Resolve(&svc); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for transient value, got %v", err)
+	}
+}
+
+// TestResolveMany tests batch resolution of several services in one call
+func TestResolveMany(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+
+	var svc *TestService
+	var dep *TestDependency
+	var withDep *TestServiceWithDep
+	if err := container.ResolveMany(&svc, &dep, &withDep); err != nil {
+		t.Fatalf("ResolveMany failed: %v", err)
+	}
+	if svc == nil || dep == nil || withDep == nil {
+		t.Fatal("All outs should be populated")
+	}
+	if withDep.Dep != dep {
+		t.Error("Singleton dependency should be shared")
+	}
+
+	// Invalid and unresolvable outs are reported together, valid ones still resolved
+	var impl *TestImpl
+	var svc2 *TestService
+	err := container.ResolveMany(nil, &impl, &svc2)
+	if !errors.Is(err, ErrInvalidOutPtr) || !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected joined ErrInvalidOutPtr and ErrServiceNotRegistered, got %v", err)
+	}
+	if svc2 != svc {
+		t.Error("Valid outs should still be resolved when others fail")
+	}
+}
+
+// TestProvideNamed tests generic named constructor registration behind an interface
+func TestProvideNamed(t *testing.T) {
+	container := NewContainer()
+	if err := ContainerProvideNamed[ITestInterface](container, "a", NewTestImpl, Singleton); err != nil {
+		t.Fatalf("ContainerProvideNamed failed: %v", err)
+	}
+	if err := ContainerProvideNamed[ITestInterface](container, "b", func() TestImplB { return TestImplB{} }, Transient); err != nil {
+		t.Fatalf("ContainerProvideNamed failed: %v", err)
+	}
+
+	var a, b ITestInterface
+	container.MustResolveNamed("a", &a)
+	container.MustResolveNamed("b", &b)
+	if a.GetValue() != "impl" || b.GetValue() != "implB" {
+		t.Errorf("Unexpected values: '%s', '%s'", a.GetValue(), b.GetValue())
+	}
+
+	// Return type must implement the interface
+	if err := ContainerProvideNamed[ITestInterface](container, "c", NewTestService, Singleton); err == nil {
+		t.Error("Expected error for non-implementing constructor")
+	}
+	// Type parameter must be an interface
+	if err := ContainerProvideNamed[*TestImpl](container, "d", NewTestImpl, Singleton); err != ErrInvalidInterfaceType {
+		t.Errorf("Expected ErrInvalidInterfaceType, got %v", err)
+	}
+	if err := ContainerProvideNamed[ITestInterface](container, "a", NewTestImpl, Singleton); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+}
+
+// TestGlobalProvideNamed tests ProvideNamed on the global container
+func TestGlobalProvideNamed(t *testing.T) {
+	GlobalReset()
+	defer GlobalReset()
+	if err := ProvideNamed[ITestInterface]("global-provide-named", NewTestImpl, Singleton); err != nil {
+		t.Fatalf("ProvideNamed failed: %v", err)
+	}
+	var impl ITestInterface
+	Global.MustResolveNamed("global-provide-named", &impl)
+	if impl.GetValue() != "impl" {
+		t.Errorf("Expected 'impl', got '%s'", impl.GetValue())
+	}
+}
+
+// TestScopeOverride tests that scope overrides shadow root singletons only within that scope
+func TestScopeOverride(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Scoped)
+	container.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton)
+
+	tenant := container.NewScope()
+	double := &TestDependency{Name: "double"}
+	if err := tenant.Override(double); err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	if err := tenant.OverrideAs(&TestImpl{Value: "tenant"}, (*ITestInterface)(nil)); err != nil {
+		t.Fatalf("OverrideAs failed: %v", err)
+	}
+
+	if ScopeMustGet[*TestDependency](tenant) != double {
+		t.Error("Scope should return override")
+	}
+	if ScopeMustGet[*TestServiceWithDep](tenant).Dep != double {
+		t.Error("Override should be injected into dependents in the scope")
+	}
+	if ScopeMustGet[ITestInterface](tenant).GetValue() != "tenant" {
+		t.Error("Interface override should be returned")
+	}
+
+	// Root and other scopes unaffected
+	var rootDep *TestDependency
+	container.MustResolve(&rootDep)
+	if rootDep == double || rootDep.Name != "dependency" {
+		t.Error("Root container should not see the override")
+	}
+	other := container.NewScope()
+	if ScopeMustGet[*TestServiceWithDep](other).Dep != rootDep {
+		t.Error("Other scope should use the root singleton")
+	}
+	if ScopeMustGet[ITestInterface](other).GetValue() != "impl" {
+		t.Error("Other scope should use the root interface registration")
+	}
+
+	if err := tenant.Override(nil); err != ErrNilInstance {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+	if err := tenant.OverrideAs(&TestService{}, (*ITestInterface)(nil)); !errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Expected ErrInstanceNotAssignable, got %v", err)
+	}
+
+	// A root Singleton first built from an overriding scope is wired to root registrations, not the scope's double
+	leaky := NewContainer()
+	leaky.MustRegister(NewTestDependency, Singleton)
+	leaky.MustRegister(NewTestServiceWithDep, Singleton)
+	faked := leaky.NewScope()
+	if err := faked.Override(double); err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	if ScopeMustGet[*TestServiceWithDep](faked).Dep == double {
+		t.Error("Singleton built in a scope must not capture the scope's override")
+	}
+	var singleton *TestServiceWithDep
+	leaky.MustResolve(&singleton)
+	if singleton.Dep == double {
+		t.Error("Scope override leaked into the root singleton cache")
+	}
+}
+
+// Interfaces for upcasting tests: IWideTest is implemented by TestImpl but not embedded in ITestInterface
+type IWideTest interface {
+	GetValue() string
+}
+
+type INarrowTest interface {
+	ITestInterface
+	Extra()
+}
+
+type TestNarrowImpl struct{ TestImpl }
+
+func (n *TestNarrowImpl) Extra() {}
+
+// TestGetTypedInterfaceUpcast tests converting an interface-typed instance to a compatible interface
+func TestGetTypedInterfaceUpcast(t *testing.T) {
+	// Stored value is an ITestInterface interface value, requested as a different compatible interface
+	var ia ITestInterface = &TestImpl{Value: "upcast"}
+	instance := reflect.ValueOf(&ia).Elem()
+
+	ib, err := getTyped[IWideTest](nil, reflect.TypeOf((*IWideTest)(nil)).Elem(), instance)
+	if err != nil {
+		t.Fatalf("getTyped failed: %v", err)
+	}
+	if ib.GetValue() != "upcast" {
+		t.Errorf("Expected 'upcast', got '%s'", ib.GetValue())
+	}
+
+	// Narrow interface value to the interface it embeds
+	var narrow INarrowTest = &TestNarrowImpl{TestImpl{Value: "narrow"}}
+	wide, err := getTyped[ITestInterface](nil, reflect.TypeOf((*ITestInterface)(nil)).Elem(), reflect.ValueOf(&narrow).Elem())
+	if err != nil || wide.GetValue() != "narrow" {
+		t.Errorf("Expected 'narrow', got %v / %v", wide, err)
+	}
+
+	// Dynamic value that does not satisfy the target interface
+	if _, err = getTyped[INarrowTest](nil, reflect.TypeOf((*INarrowTest)(nil)).Elem(), instance); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed, got %v", err)
+	}
+
+	// Nil interface value
+	var nilIface ITestInterface
+	if _, err = getTyped[IWideTest](nil, reflect.TypeOf((*IWideTest)(nil)).Elem(), reflect.ValueOf(&nilIface).Elem()); err != nil {
+		t.Errorf("Nil interface assignable to an identical method set should succeed, got %v", err)
+	}
+
+	// Through a factory-registered interface resolved via Get
+	GlobalReset()
+	defer GlobalReset()
+	RegisterFactory[ITestInterface](func(c *Container) (ITestInterface, error) {
+		return &TestImpl{Value: "factory"}, nil
+	}, Singleton)
+	if MustGet[ITestInterface]().GetValue() != "factory" {
+		t.Error("Expected factory instance")
+	}
+}
+
+// TestDanglingServices tests the static check for registrations with unresolvable parameters
+func TestDanglingServices(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestServiceWithDep, Singleton) // *TestDependency missing
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegister(func(all []*TestImpl, byName map[string]*TestImpl, name ServiceName) *TestImpl {
+		return &TestImpl{}
+	}, Transient) // slices, maps and ServiceName are always satisfiable
+	container.MustRegister(func(svc *TestService, iface ITestInterface) TestImplB {
+		return TestImplB{}
+	}, Transient) // ITestInterface missing
+	container.MustRegisterInstance(&TestDependency{}, Singleton)
+
+	dangling := container.DanglingServices()
+	if len(dangling) != 1 {
+		t.Fatalf("Expected 1 dangling service, got %v", dangling)
+	}
+	if dangling[0] != reflect.TypeOf(TestImplB{}) {
+		t.Errorf("Expected TestImplB to be dangling, got %v", dangling)
+	}
+
+	container2 := NewContainer()
+	container2.MustRegister(NewTestServiceWithDep, Singleton)
+	container2.MustRegister(func(i ITestInterface) *TestService { return nil }, Singleton)
+	dangling = container2.DanglingServices()
+	if len(dangling) != 2 || dangling[0] != reflect.TypeOf(&TestServiceWithDep{}) || dangling[1] != reflect.TypeOf(&TestService{}) {
+		t.Errorf("Expected dangling services in registration order, got %v", dangling)
+	}
+}
+
+// TestValidateCaptiveDependency tests that Validate flags Singletons depending on Scoped services
+func TestValidateCaptiveDependency(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Scoped)
+	container.MustRegister(NewTestServiceWithDep, Singleton)
+
+	err := container.Validate()
+	if !errors.Is(err, ErrCaptiveDependency) {
+		t.Fatalf("Expected ErrCaptiveDependency, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "*gofac.TestServiceWithDep -> *gofac.TestDependency") {
+		t.Errorf("Expected the offending pair in the error, got %v", err)
+	}
+
+	// Through a Transient service, and for named Singletons
+	container = NewContainer()
+	container.MustRegister(NewTestDependency, Scoped)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	container.MustRegisterNamed("captive", func(s *TestServiceWithDep) *TestService { return &TestService{} }, Singleton)
+	err = container.Validate()
+	if !errors.Is(err, ErrCaptiveDependency) || !strings.Contains(err.Error(), "*gofac.TestService [captive] -> *gofac.TestServiceWithDep -> *gofac.TestDependency") {
+		t.Errorf("Expected transitive captive dependency, got %v", err)
+	}
+
+	// Scoped/Transient consumers, Singleton dependencies and Scoped instances are fine
+	container = NewContainer()
+	container.MustRegister(NewTestDependency, Scoped)
+	container.MustRegister(NewTestServiceWithDep, Scoped)
+	container.MustRegisterInstance(&TestImpl{}, Scoped)
+	container.MustRegister(func(i *TestImpl) *TestService { return &TestService{} }, Singleton)
+	if err := container.Validate(); err != nil {
+		t.Errorf("Expected no captive dependency, got %v", err)
+	}
+}
+
+// TestUnusedRegistrations tests reporting registrations never resolved while stats are enabled
+func TestUnusedRegistrations(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegisterInstance(&TestImpl{}, Singleton)
+
+	if unused := container.UnusedRegistrations(); unused != nil {
+		t.Errorf("Expected nil without stats, got %v", unused)
+	}
+	container.EnableStats(true)
+	var svc *TestService
+	container.MustResolve(&svc)
+
+	unused := container.UnusedRegistrations()
+	expected := []reflect.Type{reflect.TypeOf(&TestDependency{}), reflect.TypeOf(&TestImpl{})}
+	if !reflect.DeepEqual(unused, expected) {
+		t.Errorf("Expected %v unused in registration order, got %v", expected, unused)
+	}
+
+	// Dependencies and instance fast-path resolutions count as used
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	var withDep *TestServiceWithDep
+	container.NewScope().MustResolve(&withDep)
+	var impl *TestImpl
+	container.MustResolve(&impl)
+	if unused := container.UnusedRegistrations(); len(unused) != 0 {
+		t.Errorf("Expected no unused registrations, got %v", unused)
+	}
+}
+
+// Context-aware constructor test types
+type ctxKey struct{}
+
+type TestDialer struct {
+	Target string
+	Dep    *TestDependency
+}
+
+// TestResolveContext tests injecting the resolution context into constructors
+func TestResolveContext(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(func(ctx context.Context, dep *TestDependency) *TestDialer {
+		target, _ := ctx.Value(ctxKey{}).(string)
+		return &TestDialer{Target: target, Dep: dep}
+	}, Transient)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "db:5432")
+	var dialer *TestDialer
+	if err := container.ResolveContext(ctx, &dialer); err != nil {
+		t.Fatalf("ResolveContext failed: %v", err)
+	}
+	if dialer.Target != "db:5432" || dialer.Dep == nil {
+		t.Errorf("Expected context and dependency to be injected, got %+v", dialer)
+	}
+
+	// No context supplied and none registered
+	err := container.Resolve(&dialer)
+	if !errors.Is(err, ErrContextRequired) {
+		t.Errorf("Expected ErrContextRequired, got %v", err)
+	}
+
+	// Registered context is used as fallback
+	container.MustRegisterInstanceAs(context.WithValue(context.Background(), ctxKey{}, "registered"), (*context.Context)(nil), Singleton)
+	container.MustResolve(&dialer)
+	if dialer.Target != "registered" {
+		t.Errorf("Expected registered context, got '%s'", dialer.Target)
+	}
+
+	if err = container.ResolveContext(ctx, nil); err != ErrInvalidOutPtr {
+		t.Errorf("Expected ErrInvalidOutPtr, got %v", err)
+	}
+}
+
+// TestGlobalResetPristine tests that GlobalReset drops named services and options as well
+func TestGlobalResetPristine(t *testing.T) {
+	GlobalReset()
+	Global.MustRegisterInstanceNamed("leftover", &TestService{Value: "leftover"}, Singleton)
+	Global.SetAutoDeref(true)
+	MustRegister(NewTestService, Singleton)
+
+	GlobalReset()
+	defer GlobalReset()
+
+	var all []*TestService
+	Global.MustResolveAll(&all)
+	if len(all) != 0 {
+		t.Errorf("Expected no services after GlobalReset, got %d", len(all))
+	}
+	if Global.autoDeref {
+		t.Error("Options should be restored to defaults after GlobalReset")
+	}
+
+	// Reset is idempotent
+	GlobalReset()
+	if _, err := Get[*TestService](); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+// TestResetClearsNamedServices tests that Container.Reset also clears named services
+func TestResetClearsNamedServices(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceNamed("a", &TestService{}, Singleton)
+	container.Reset()
+
+	var svc *TestService
+	if err := container.ResolveNamed("a", &svc); err == nil {
+		t.Error("Expected named service to be cleared by Reset")
+	}
+}
+
+// TestScopeResolveContextCancellation tests that resolution stops once the scope's context is canceled
+func TestScopeResolveContextCancellation(t *testing.T) {
+	container := NewContainer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Building the dependency cancels the request; the dependent must not be constructed
+	container.MustRegister(func() *TestDependency {
+		cancel()
+		return &TestDependency{}
+	}, Scoped)
+	dependentBuilt := false
+	container.MustRegister(func(dep *TestDependency) *TestServiceWithDep {
+		dependentBuilt = true
+		return &TestServiceWithDep{Dep: dep}
+	}, Scoped)
+
+	scope := container.NewScope()
+	var svc *TestServiceWithDep
+	err := scope.ResolveContext(ctx, &svc)
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrResolveCanceled) {
+		t.Fatalf("Expected ErrResolveCanceled wrapping context.Canceled, got %v", err)
+	}
+	if dependentBuilt {
+		t.Error("Dependent constructor should not run after cancellation")
+	}
+
+	// Already canceled context: nothing is built
+	scope2 := container.NewScope()
+	var dep *TestDependency
+	if err = scope2.ResolveContext(ctx, &dep); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// Live context resolves normally and is injected
+	container.MustRegister(func(ctx context.Context) *TestService {
+		return &TestService{Value: ctx.Value(ctxKey{}).(string)}
+	}, Scoped)
+	var result *TestService
+	liveCtx := context.WithValue(context.Background(), ctxKey{}, "live")
+	if err = container.NewScope().ResolveContext(liveCtx, &result); err != nil || result.Value != "live" {
+		t.Errorf("Expected live resolution, got %v / %v", result, err)
+	}
+}
+
+// TestKeyedRegistration tests typed registration tokens
+func TestKeyedRegistration(t *testing.T) {
+	primaryKey := NewKey[*TestService]("primary")
+	replicaKey := NewKey[*TestService]("replica")
+	implKey := NewKey[ITestInterface]("impl")
+
+	container := NewContainer()
+	if err := ContainerRegisterKeyed(container, primaryKey, func() *TestService { return &TestService{Value: "primary"} }, Singleton); err != nil {
+		t.Fatalf("ContainerRegisterKeyed failed: %v", err)
+	}
+	ContainerRegisterKeyed(container, replicaKey, func() *TestService { return &TestService{Value: "replica"} }, Transient)
+	ContainerRegisterKeyed(container, implKey, NewTestImpl, Singleton)
+
+	// The key fixes the result type at compile time: ContainerGetKeyed(container, primaryKey) returns *TestService,
+	// and e.g. ContainerRegisterKeyed(container, implKey, ...) followed by a *TestService assignment does not compile.
+	primary, err := ContainerGetKeyed(container, primaryKey)
+	if err != nil {
+		t.Fatalf("ContainerGetKeyed failed: %v", err)
+	}
+	if primary.Value != "primary" {
+		t.Errorf("Expected 'primary', got '%s'", primary.Value)
+	}
+	again, _ := ContainerGetKeyed(container, primaryKey)
+	if again != primary {
+		t.Error("Keyed singleton should be cached")
+	}
+	replica, _ := ContainerGetKeyed(container, replicaKey)
+	if replica.Value != "replica" {
+		t.Errorf("Expected 'replica', got '%s'", replica.Value)
+	}
+	impl, err := ContainerGetKeyed(container, implKey)
+	if err != nil || impl.GetValue() != "impl" {
+		t.Errorf("Expected interface keyed service, got %v / %v", impl, err)
+	}
+
+	// Keys with the same name are still distinct tokens
+	lookalike := NewKey[*TestService]("primary")
+	if _, err = ContainerGetKeyed(container, lookalike); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for distinct key, got %v", err)
+	}
+
+	if err = ContainerRegisterKeyed(container, primaryKey, NewTestService, Singleton); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	if err = ContainerRegisterKeyed(container, NewKey[*TestService]("wrong"), NewTestDependency, Singleton); err == nil {
+		t.Error("Expected error for constructor not assignable to key type")
+	}
+	if err = ContainerRegisterKeyed(container, Key[*TestService]{}, NewTestService, Singleton); err == nil {
+		t.Error("Expected error for zero key")
+	}
+}
+
+// TestGlobalKeyedRegistration tests keyed registration on the global container
+func TestGlobalKeyedRegistration(t *testing.T) {
+	GlobalReset()
+	defer GlobalReset()
+
+	key := NewKey[*TestDependency]("dep")
+	if err := RegisterKeyed(key, NewTestDependency, Singleton); err != nil {
+		t.Fatalf("RegisterKeyed failed: %v", err)
+	}
+	dep, err := GetKeyed(key)
+	if err != nil || dep.Name != "dependency" {
+		t.Errorf("Expected keyed dependency, got %v / %v", dep, err)
+	}
+}
+
+// Field injection test types
+type ILogger interface {
+	Log(msg string) string
+}
+
+type TestLogger struct{}
+
+func (l *TestLogger) Log(msg string) string {
+	return "log: " + msg
+}
+
+type TestHandler struct {
+	ILogger                   // Embedded interface: injected without tag
+	Dep       *TestDependency `inject:""` // Resolved by type
+	Primary   *TestService    `inject:"primary"`
+	Untouched *TestService    // No tag: left alone
+	Skipped   ITestInterface  `inject:"-"`
+}
+
+// TestPopulateEmbeddedInterface tests field injection including embedded interface fields
+func TestPopulateEmbeddedInterface(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterAs(func() *TestLogger { return &TestLogger{} }, (*ILogger)(nil), Singleton)
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegisterInstanceNamed("primary", &TestService{Value: "primary"}, Singleton)
+	container.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton)
+
+	var handler TestHandler
+	if err := container.Populate(&handler); err != nil {
+		t.Fatalf("Populate failed: %v", err)
+	}
+	if handler.ILogger == nil || handler.Log("hi") != "log: hi" {
+		t.Error("Embedded interface should be injected and usable through promotion")
+	}
+	if handler.Dep == nil || handler.Primary == nil || handler.Primary.Value != "primary" {
+		t.Errorf("Tagged fields should be injected, got %+v", handler)
+	}
+	if handler.Untouched != nil || handler.Skipped != nil {
+		t.Error("Untagged and skipped fields should be left alone")
+	}
+
+	// Embedded interface with a name tag
+	type NamedEmbed struct {
+		ILogger `inject:"audit"`
+	}
+	container.MustRegisterInstanceAsNamed("audit", &TestLogger{}, (*ILogger)(nil), Singleton)
+	var named NamedEmbed
+	container.MustPopulate(&named)
+	if named.ILogger == nil {
+		t.Error("Embedded interface should respect inject tag")
+	}
+
+	// Errors
+	var missing struct {
+		Impl *TestImpl `inject:""`
+	}
+	if err := container.Populate(&missing); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+	if err := container.Populate(handler); !errors.Is(err, ErrInvalidOutPtr) {
+		t.Errorf("Expected ErrInvalidOutPtr for non-pointer, got %v", err)
+	}
+}
+
+// TestPopulateNamed tests filling tagged struct fields from named registrations
+func TestPopulateNamed(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceNamed("primary", &TestDependency{Name: "primary"}, Singleton)
+	container.MustRegisterInstanceNamed("replica1", &TestDependency{Name: "replica1"}, Singleton)
+
+	type DBs struct {
+		Primary  *TestDependency `name:"primary"`
+		Replica1 *TestDependency `name:"replica1"`
+		Other    *TestDependency
+	}
+	untouched := &TestDependency{Name: "untouched"}
+	dbs := DBs{Other: untouched}
+	if err := container.PopulateNamed(&dbs); err != nil {
+		t.Fatalf("PopulateNamed failed: %v", err)
+	}
+	if dbs.Primary.Name != "primary" || dbs.Replica1.Name != "replica1" || dbs.Other != untouched {
+		t.Errorf("Unexpected populated struct: %+v", dbs)
+	}
+
+	// Missing names report the field
+	var missing struct {
+		Replica2 *TestDependency `name:"replica2"`
+	}
+	err := container.PopulateNamed(&missing)
+	if !errors.Is(err, ErrServiceNotRegistered) || !strings.Contains(err.Error(), "Replica2") {
+		t.Errorf("Expected ErrServiceNotRegistered naming the field, got %v", err)
+	}
+
+	if err := container.PopulateNamed(dbs); !errors.Is(err, ErrInvalidOutPtr) {
+		t.Errorf("Expected ErrInvalidOutPtr for non-pointer target, got %v", err)
+	}
+}
+
+// TestInstanceFastPath tests that the instance fast path respects lifetimes and scope overrides
+func TestInstanceFastPath(t *testing.T) {
+	container := NewContainer()
+	instance := &TestService{Value: "instance"}
+	container.MustRegisterInstance(instance, Singleton)
+	container.MustRegisterInstance(&TestDependency{}, Scoped)
+
+	var svc *TestService
+	container.MustResolve(&svc)
+	if svc != instance {
+		t.Error("Fast path should return the registered instance")
+	}
+
+	// Scoped instances still go through full resolution
+	var dep *TestDependency
+	if err := container.Resolve(&dep); err != ErrScopedOnRootContainer {
+		t.Errorf("Expected ErrScopedOnRootContainer, got %v", err)
+	}
+
+	scope := container.NewScope()
+	override := &TestService{Value: "override"}
+	scope.Override(override)
+	scope.MustResolve(&svc)
+	if svc != override {
+		t.Error("Scope override should take precedence over the fast path")
+	}
+	if ScopeMustGet[*TestService](scope) != override {
+		t.Error("ScopeGet should respect scope override")
+	}
+}
+
+// BenchmarkResolveInstance measures allocations when resolving a pre-registered instance (fast path)
+func BenchmarkResolveInstance(b *testing.B) {
+	container := NewContainer()
+	container.MustRegisterInstance(&TestService{Value: "instance"}, Singleton)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var svc *TestService
+		if err := container.Resolve(&svc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkResolveCachedSingleton measures allocations when resolving a constructed singleton (full resolution path)
+func BenchmarkResolveCachedSingleton(b *testing.B) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var svc *TestService
+		if err := container.Resolve(&svc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkResolveTransientWithDependency measures allocations of a full recursive resolution (pooled resolution state)
+func BenchmarkResolveTransientWithDependency(b *testing.B) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var svc *TestServiceWithDep
+		if err := container.Resolve(&svc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkImplementsScan measures interface resolution through EnableImplementsScan among many registrations, with the
+// implements cache and with the cache dropped before every resolution
+func BenchmarkImplementsScan(b *testing.B) {
+	container := NewContainer()
+	container.EnableImplementsScan(true)
+	for i := 1; i <= 200; i++ {
+		container.MustRegisterInstance(reflect.New(reflect.ArrayOf(i, reflect.TypeOf(0))).Elem().Interface(), Singleton)
+	}
+	container.MustRegisterInstance(NewTestImpl(), Singleton)
+
+	resolve := func(b *testing.B, uncached bool) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if uncached {
+				container.mu.Lock()
+				container.invalidateImplementsLocked()
+				container.mu.Unlock()
+			}
+			var iface ITestInterface
+			if err := container.Resolve(&iface); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.Run("cached", func(b *testing.B) { resolve(b, false) })
+	b.Run("uncached", func(b *testing.B) { resolve(b, true) })
+}
+
+// TestResolveTrackPoolReuse tests that pooled resolution state is cleared between resolves
+func TestResolveTrackPoolReuse(t *testing.T) {
+	track := newResolveTrack(context.Background())
+	track.visiting[reflect.TypeOf(&TestService{})] = true
+	track.release()
+
+	reused := newResolveTrack(nil)
+	defer reused.release()
+	if len(reused.visiting) != 0 || reused.ctx != nil {
+		t.Error("Pooled resolution state should be cleared on release")
+	}
+
+	// Repeated resolutions, including failing ones, must not leave stale cycle markers behind
+	container := NewContainer()
+	container.MustRegister(NewServiceA, Transient)
+	container.MustRegister(NewServiceB, Transient)
+	container.MustRegister(NewTestService, Transient)
+	for i := 0; i < 3; i++ {
+		var a *ServiceA
+		if err := container.Resolve(&a); !errors.Is(err, ErrResolveCircularDependency) {
+			t.Fatalf("Expected ErrResolveCircularDependency, got %v", err)
+		}
+		var svc *TestService
+		if err := container.Resolve(&svc); err != nil {
+			t.Fatalf("Resolve after failed resolution should succeed, got %v", err)
+		}
+	}
+}
+
+// Alias and defined types over reference types
+type ISettings = map[string]string
+
+type DefinedSettings map[string]string
+
+type AliasRequest struct{ Path string }
+
+type AliasResponse struct{ Status int }
+
+type Handler = func(AliasRequest) AliasResponse
+
+type DefinedHandler func(AliasRequest) AliasResponse
+
+// TestResolveAliasType tests that a type alias is the same service type as the aliased type
+func TestResolveAliasType(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstance(ISettings{"db_host": "localhost"}, Singleton)
+	container.MustRegisterInstance(Handler(func(r AliasRequest) AliasResponse { return AliasResponse{Status: 200} }), Singleton)
+
+	// Resolving by the alias and by the aliased type yields the same registration
+	var byAlias ISettings
+	container.MustResolve(&byAlias)
+	var byUnderlying map[string]string
+	container.MustResolve(&byUnderlying)
+	if byAlias["db_host"] != "localhost" || byUnderlying["db_host"] != "localhost" {
+		t.Errorf("Expected alias and aliased type to resolve the same settings, got %v and %v", byAlias, byUnderlying)
+	}
+
+	var handler Handler
+	container.MustResolve(&handler)
+	var fn func(AliasRequest) AliasResponse
+	container.MustResolve(&fn)
+	if handler(AliasRequest{}).Status != 200 || fn(AliasRequest{}).Status != 200 {
+		t.Error("Expected alias and aliased func type to resolve the same handler")
+	}
+
+	// Registering the aliased type again is a duplicate of the alias registration
+	if err := container.RegisterInstance(map[string]string{}, Singleton); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+}
+
+// TestResolveDefinedType tests that a defined type over a map/func is a distinct service type from its underlying type
+func TestResolveDefinedType(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstance(DefinedSettings{"db_host": "localhost"}, Singleton)
+	container.MustRegisterInstance(DefinedHandler(func(r AliasRequest) AliasResponse { return AliasResponse{Status: 200} }), Singleton)
+
+	var settings DefinedSettings
+	container.MustResolve(&settings)
+	if settings["db_host"] != "localhost" {
+		t.Errorf("Expected defined settings, got %v", settings)
+	}
+	var handler DefinedHandler
+	container.MustResolve(&handler)
+	if handler(AliasRequest{}).Status != 200 {
+		t.Error("Expected defined handler to be resolved")
+	}
+
+	// The underlying types are not registered: Resolve and Get agree
+	var raw map[string]string
+	if err := container.Resolve(&raw); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for underlying map type, got %v", err)
+	}
+	var rawFn func(AliasRequest) AliasResponse
+	if err := container.Resolve(&rawFn); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for underlying func type, got %v", err)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	MustRegisterInstance(DefinedSettings{"db_host": "localhost"}, Singleton)
+	if got := MustGet[DefinedSettings](); got["db_host"] != "localhost" {
+		t.Errorf("Expected Get by defined type to succeed, got %v", got)
+	}
+	if _, err := Get[map[string]string](); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected Get by underlying type to fail with ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+// TestRegisterInstanceAsConvertibleDefinedType tests that an instance registered under a convertible defined type
+// resolves as exactly the registered type
+func TestRegisterInstanceAsConvertibleDefinedType(t *testing.T) {
+	container := NewContainer()
+	settings := &DefinedSettings{"db_host": "localhost"}
+	container.MustRegisterInstanceAs(settings, (*map[string]string)(nil), Singleton)
+
+	var resolved *map[string]string
+	if err := container.Resolve(&resolved); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if (*resolved)["db_host"] != "localhost" {
+		t.Errorf("Expected converted settings, got %v", *resolved)
+	}
+	// Conversion keeps the same pointer, so the registered instance is shared
+	(*resolved)["db_port"] = "5432"
+	if (*settings)["db_port"] != "5432" {
+		t.Error("Expected resolved pointer to share the registered instance")
+	}
+}
+
+type OtherSettings map[string]string
+
+type DefinedPort int
+
+// TestMatchUnderlyingTypes tests opt-in matching between defined types and their underlying types
+func TestMatchUnderlyingTypes(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstance(DefinedSettings{"db_host": "localhost"}, Singleton)
+	container.MustRegisterInstance(8080, Singleton)
+
+	// Off by default: defined and underlying types stay distinct
+	var raw map[string]string
+	if err := container.Resolve(&raw); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("Expected ErrServiceNotRegistered by default, got %v", err)
+	}
+
+	container.MatchUnderlyingTypes(true)
+	// Defined -> underlying: the map is shared, not copied
+	container.MustResolve(&raw)
+	raw["db_port"] = "5432"
+	var settings DefinedSettings
+	container.MustResolve(&settings)
+	if settings["db_port"] != "5432" {
+		t.Error("Expected underlying map to share the registered defined settings")
+	}
+	// Underlying -> defined, also through a scope
+	var port DefinedPort
+	container.NewScope().MustResolve(&port)
+	if port != 8080 {
+		t.Errorf("Expected DefinedPort 8080, got %d", port)
+	}
+
+	// Two defined types never match each other
+	var other OtherSettings
+	if err := container.Resolve(&other); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for a sibling defined type, got %v", err)
+	}
+	// Same kind is required: int does not satisfy int64
+	var wide int64
+	if err := container.Resolve(&wide); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for int64, got %v", err)
+	}
+
+	// Several defined types over the requested underlying type are ambiguous
+	container.MustRegisterInstance(OtherSettings{}, Singleton)
+	if err := container.Resolve(&raw); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution, got %v", err)
+	}
+
+	// StrictTypes disables the conversion
+	container.StrictTypes(true)
+	if err := container.Resolve(&port); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered under StrictTypes, got %v", err)
+	}
+}
+
+// TestContainerRegisterInstanceAsGeneric tests instance registration with the interface given as type parameter
+func TestContainerRegisterInstanceAsGeneric(t *testing.T) {
+	container := NewContainer()
+	if err := ContainerRegisterInstanceAs[ITestInterface](container, NewTestImpl(), Singleton); err != nil {
+		t.Fatalf("ContainerRegisterInstanceAs failed: %v", err)
+	}
+	var svc ITestInterface
+	container.MustResolve(&svc)
+	if svc.GetValue() != "impl" {
+		t.Errorf("Expected 'impl', got %s", svc.GetValue())
+	}
+
+	// Instance not implementing the interface
+	if err := ContainerRegisterInstanceAs[io.Reader](container, &TestService{}, Singleton); !errors.Is(err, ErrInstanceNotAssignable) {
+		t.Errorf("Expected ErrInstanceNotAssignable, got %v", err)
+	}
+	// Type parameter is not an interface
+	if err := ContainerRegisterInstanceAs[*TestService](container, &TestService{}, Singleton); !errors.Is(err, ErrInvalidInterfaceType) {
+		t.Errorf("Expected ErrInvalidInterfaceType, got %v", err)
+	}
+	// Transient is still rejected for instances
+	if err := ContainerRegisterInstanceAs[io.Reader](container, strings.NewReader("x"), Transient); !errors.Is(err, ErrTransientInstance) {
+		t.Errorf("Expected ErrTransientInstance, got %v", err)
+	}
+}
+
+// TestRegisterInstanceNamedGeneric tests that typed named instance registration is keyed by the static type
+func TestRegisterInstanceNamedGeneric(t *testing.T) {
+	container := NewContainer()
+	var iface ITestInterface = NewTestImpl()
+	if err := ContainerRegisterInstanceNamed(container, "iface", iface, Singleton); err != nil {
+		t.Fatalf("ContainerRegisterInstanceNamed failed: %v", err)
+	}
+
+	// Registered as ITestInterface, not as the dynamic *TestImpl
+	var resolved ITestInterface
+	if err := container.ResolveNamed("iface", &resolved); err != nil || resolved != iface {
+		t.Errorf("Expected ResolveNamed by the static type, got %v (%v)", resolved, err)
+	}
+	var impl *TestImpl
+	if err := container.ResolveNamed("iface", &impl); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected no registration under the dynamic type, got %v", err)
+	}
+
+	// Concrete T, nil interface values and the global variant
+	if err := ContainerRegisterInstanceNamed(container, "dep", &TestDependency{Name: "dep"}, Singleton); err != nil {
+		t.Fatalf("ContainerRegisterInstanceNamed failed: %v", err)
+	}
+	var dep *TestDependency
+	if err := container.ResolveNamed("dep", &dep); err != nil || dep.Name != "dep" {
+		t.Errorf("Expected named concrete instance, got %v (%v)", dep, err)
+	}
+	var nilIface ITestInterface
+	if err := ContainerRegisterInstanceNamed(container, "nil", nilIface, Singleton); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	if err := RegisterInstanceNamed("iface", iface, Singleton); err != nil {
+		t.Fatalf("RegisterInstanceNamed failed: %v", err)
+	}
+	if err := Global.ResolveNamed("iface", &resolved); err != nil || resolved != iface {
+		t.Errorf("Expected global named registration by static type, got %v", err)
+	}
+}
+
+// TestRegisterInstanceAsGeneric tests the global container variant
+func TestRegisterInstanceAsGeneric(t *testing.T) {
+	GlobalReset()
+	defer GlobalReset()
+	if err := RegisterInstanceAs[io.Reader](strings.NewReader("data"), Singleton); err != nil {
+		t.Fatalf("RegisterInstanceAs failed: %v", err)
+	}
+	if _, err := MustGet[io.Reader]().Read(make([]byte, 4)); err != nil {
+		t.Errorf("Expected registered reader, got %v", err)
+	}
+}
+
+// TestOnFirstInit tests that the first-init callback fires exactly once across concurrent resolves
+func TestOnFirstInit(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegister(NewTestDependency, Transient)
+
+	var calls atomic.Int32
+	var seen atomic.Value
+	container.OnFirstInit(reflect.TypeOf(&TestService{}), func(instance any) {
+		calls.Add(1)
+		seen.Store(instance)
+	})
+	var depCalls atomic.Int32
+	container.OnFirstInit(reflect.TypeOf(&TestDependency{}), func(any) { depCalls.Add(1) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var svc *TestService
+			if i%2 == 0 {
+				container.MustResolve(&svc)
+			} else {
+				container.NewScope().MustResolve(&svc)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected callback to fire exactly once, fired %d times", n)
+	}
+	var svc *TestService
+	container.MustResolve(&svc)
+	if seen.Load() != svc {
+		t.Error("Expected callback to receive the cached singleton instance")
+	}
+
+	// Transient services are never cached, the callback does not fire
+	var dep *TestDependency
+	container.MustResolve(&dep)
+	if depCalls.Load() != 0 {
+		t.Errorf("Expected no callback for Transient service, fired %d times", depCalls.Load())
+	}
+}
+
+type StrictInt int64
+
+// TestStrictTypes tests that strict mode forbids ConvertibleTo adaptation while default mode keeps it
+func TestStrictTypes(t *testing.T) {
+	container := NewContainer()
+	int64Type := reflect.TypeOf(int64(0))
+
+	// Default: a registered int resolved as int64 is converted (a copy)
+	got, err := getTyped[int64](container, int64Type, reflect.ValueOf(42))
+	if err != nil || got != 42 {
+		t.Fatalf("Expected converted value 42, got %d, %v", got, err)
+	}
+
+	// Strict: conversion is rejected
+	container.StrictTypes(true)
+	if _, err := getTyped[int64](container, int64Type, reflect.ValueOf(42)); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed in strict mode, got %v", err)
+	}
+	// Assignable values still resolve in strict mode
+	if got, err := getTyped[int64](container, int64Type, reflect.ValueOf(int64(7))); err != nil || got != 7 {
+		t.Errorf("Expected assignable value 7 in strict mode, got %d, %v", got, err)
+	}
+
+	// Through the generic API: a constructor of *StrictInt registered as *int64
+	GlobalReset()
+	defer GlobalReset()
+	MustRegisterAs(func() *StrictInt { v := StrictInt(5); return &v }, (*int64)(nil), Singleton)
+	if got := MustGet[*int64](); *got != 5 {
+		t.Errorf("Expected 5, got %d", *got)
+	}
+	Global.StrictTypes(true)
+	if _, err := Get[*int64](); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed in strict mode, got %v", err)
+	}
+
+	// Through the public Resolve of container and scope, and constructor injection: converted by default, an error
+	// (never a reflect panic) under StrictTypes
+	container = NewContainer()
+	container.MustRegisterAs(func() *StrictInt { v := StrictInt(9); return &v }, (*int64)(nil), Singleton)
+	container.MustRegister(func(v *int64) *TestDependency { return &TestDependency{Name: strconv.FormatInt(*v, 10)} }, Transient)
+	var ptr *int64
+	if err := container.Resolve(&ptr); err != nil || *ptr != 9 {
+		t.Errorf("Expected converted *int64, got %v, %v", ptr, err)
+	}
+	var dep *TestDependency
+	if err := container.NewScope().Resolve(&dep); err != nil || dep.Name != "9" {
+		t.Errorf("Expected converted injection, got %v, %v", dep, err)
+	}
+	container.StrictTypes(true)
+	if err := container.Resolve(&ptr); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed from Resolve, got %v", err)
+	}
+	if err := container.NewScope().Resolve(&ptr); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed from Scope.Resolve, got %v", err)
+	}
+	if err := container.Resolve(&dep); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed for an injected parameter, got %v", err)
+	}
+}
+
+type ValidateFunc = func(string) error
+
+// TestResolveFuncType tests resolving registered func instances into func variables (Resolve, Get and aliases)
+func TestResolveFuncType(t *testing.T) {
+	errEmpty := errors.New("empty")
+	validate := func(s string) error {
+		if s == "" {
+			return errEmpty
+		}
+		return nil
+	}
+
+	container := NewContainer()
+	container.MustRegisterInstance(validate, Singleton)
+
+	var fn func(string) error
+	if err := container.Resolve(&fn); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if fn("") != errEmpty || fn("x") != nil {
+		t.Error("Expected resolved func to be the registered validator")
+	}
+
+	// Alias of the registered func type resolves the same registration
+	var aliased ValidateFunc
+	container.MustResolve(&aliased)
+	if aliased("") != errEmpty {
+		t.Error("Expected aliased func type to resolve the registered validator")
+	}
+
+	// getTyped handles func targets through the assignable path
+	typed, err := getTyped[ValidateFunc](container, reflect.TypeOf(validate), reflect.ValueOf(validate))
+	if err != nil || typed("") != errEmpty {
+		t.Errorf("Expected getTyped to return the validator, got %v", err)
+	}
+
+	// Func types with a different signature are distinct services
+	var other func(int) error
+	if err := container.Resolve(&other); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+
+	// Func dependencies are injected into constructors
+	type Form struct{ Validate func(string) error }
+	container.MustRegister(func(v ValidateFunc) *Form { return &Form{Validate: v} }, Transient)
+	var form *Form
+	container.MustResolve(&form)
+	if form.Validate("") != errEmpty {
+		t.Error("Expected func dependency to be injected")
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	MustRegisterInstance(validate, Singleton)
+	if MustGet[func(string) error]()("") != errEmpty || MustGet[ValidateFunc]()("") != errEmpty {
+		t.Error("Expected Get to resolve the registered validator")
+	}
+}
+
+// TestProvideAll tests batch constructor registration with error aggregation and StopOnError
+func TestProvideAll(t *testing.T) {
+	container := NewContainer()
+	if err := container.ProvideAll(Singleton, NewTestService, NewTestDependency, NewTestServiceWithDep); err != nil {
+		t.Fatalf("ProvideAll failed: %v", err)
+	}
+	var svc *TestServiceWithDep
+	container.MustResolve(&svc)
+	var dep *TestDependency
+	container.MustResolve(&dep)
+	if svc.Dep != dep {
+		t.Error("Expected all constructors registered as Singleton")
+	}
+
+	// Default: every constructor is attempted, errors name the failed ones
+	container = NewContainer()
+	err := container.ProvideAll(Singleton, NewTestService, "not a ctor", NewTestService, NewTestDependency)
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected aggregated ErrRegisterDuplicate, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "constructor #1 (string)") || !strings.Contains(err.Error(), "constructor #2") {
+		t.Errorf("Expected errors naming the failed constructors, got %v", err)
+	}
+	if !container.isRegistered(reflect.TypeOf(&TestDependency{})) {
+		t.Error("Expected constructors after a failure to be registered")
+	}
+
+	// StopOnError: registration stops at the first failure
+	container = NewContainer()
+	err = container.ProvideAll(Singleton, StopOnError(), NewTestService, "not a ctor", NewTestDependency)
+	if err == nil || strings.Contains(err.Error(), "constructor #3") {
+		t.Errorf("Expected only the first failure, got %v", err)
+	}
+	if container.isRegistered(reflect.TypeOf(&TestDependency{})) {
+		t.Error("Expected constructors after the first failure not to be registered")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustProvideAll to panic")
+		}
+	}()
+	NewContainer().MustProvideAll(Transient, 42)
+}
+
+// TestResolveNamedGroup tests prefix-matched named resolution with the prefix stripped and conversion to T
+func TestResolveNamedGroup(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceNamed("db.primary", &TestService{Value: "primary"}, Singleton)
+	container.MustRegisterInstanceNamed("db.replica1", &TestService{Value: "replica1"}, Singleton)
+	container.MustRegisterInstanceNamed("cache.main", &TestService{Value: "cache"}, Singleton)
+	container.MustRegisterNamed("db.lazy", func() *TestService { return &TestService{Value: "lazy"} }, Singleton)
+
+	group, err := ContainerResolveNamedGroup[*TestService](container, "db.")
+	if err != nil {
+		t.Fatalf("ContainerResolveNamedGroup failed: %v", err)
+	}
+	if len(group) != 3 || group["primary"].Value != "primary" || group["replica1"].Value != "replica1" || group["lazy"].Value != "lazy" {
+		t.Errorf("Expected db.* services keyed without prefix, got %v", group)
+	}
+
+	// Interface T: named registrations implementing it are converted
+	container.MustRegisterInstanceNamed("impl.a", NewTestImpl(), Singleton)
+	container.MustRegisterInstanceNamed("impl.b", TestImplB{}, Singleton)
+	impls, err := ContainerResolveNamedGroup[ITestInterface](container, "impl.")
+	if err != nil {
+		t.Fatalf("ContainerResolveNamedGroup failed: %v", err)
+	}
+	if len(impls) != 2 || impls["a"].GetValue() != "impl" || impls["b"].GetValue() != "implB" {
+		t.Errorf("Expected converted interface implementations, got %v", impls)
+	}
+
+	// No match yields an empty map
+	if none, err := ContainerResolveNamedGroup[*TestService](container, "queue."); err != nil || len(none) != 0 {
+		t.Errorf("Expected empty group, got %v, %v", none, err)
+	}
+
+	// Scoped named services cannot be resolved on the root container
+	container.MustRegisterNamed("db.scoped", func() *TestService { return &TestService{} }, Scoped)
+	if _, err := ContainerResolveNamedGroup[*TestService](container, "db."); !errors.Is(err, ErrScopedOnRootContainer) {
+		t.Errorf("Expected ErrScopedOnRootContainer, got %v", err)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	Global.MustRegisterInstanceNamed("db.primary", &TestService{Value: "primary"}, Singleton)
+	if group, err := ResolveNamedGroup[*TestService]("db."); err != nil || group["primary"] == nil {
+		t.Errorf("Expected global group resolution, got %v, %v", group, err)
+	}
+}
+
+// TestNestedScopeInheritance tests that child scopes reuse Scoped instances built in enclosing scopes
+func TestNestedScopeInheritance(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Scoped)
+	container.MustRegister(NewTestDependency, Scoped)
+
+	parent := container.NewScope()
+	var parentSvc *TestService
+	parent.MustResolve(&parentSvc)
+
+	// Built in the parent: the child reuses it
+	child := parent.NewScope()
+	var childSvc *TestService
+	child.MustResolve(&childSvc)
+	if childSvc != parentSvc {
+		t.Error("Expected child scope to reuse the parent's Scoped instance")
+	}
+
+	// Absent in both: the child creates and owns a new instance, invisible to the parent
+	var childDep *TestDependency
+	child.MustResolve(&childDep)
+	var parentDep *TestDependency
+	parent.MustResolve(&parentDep)
+	if childDep == parentDep {
+		t.Error("Expected instance created in the child not to leak into the parent")
+	}
+	// Once the parent has its own, new children inherit the parent's
+	var grandchildDep *TestDependency
+	parent.NewScope().NewScope().MustResolve(&grandchildDep)
+	if grandchildDep != parentDep {
+		t.Error("Expected nested scopes to reuse the nearest enclosing instance")
+	}
+
+	// Sibling scopes stay isolated
+	var siblingDep *TestDependency
+	parent.NewScope().MustResolve(&siblingDep)
+	if siblingDep == childDep {
+		t.Error("Expected sibling scopes not to share instances created in each other")
+	}
+
+	// Overrides of enclosing scopes apply to the child
+	override := &TestService{Value: "override"}
+	overridden := container.NewScope()
+	if err := overridden.Override(override); err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	var got *TestService
+	overridden.NewScope().MustResolve(&got)
+	if got != override {
+		t.Error("Expected parent override to apply in the child scope")
+	}
+}
+
+// TestRegisterComputed tests that a computed singleton is evaluated lazily, cached, and recomputed after ClearCaches
+func TestRegisterComputed(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstance(&TestDependency{Name: "db"}, Singleton)
+
+	var calls atomic.Int32
+	err := ContainerRegisterComputed(container, func(c *Container) *TestService {
+		calls.Add(1)
+		var dep *TestDependency
+		c.MustResolve(&dep)
+		return &TestService{Value: "computed from " + dep.Name}
+	})
+	if err != nil {
+		t.Fatalf("ContainerRegisterComputed failed: %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Error("Expected computation to be lazy")
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*TestService, 20)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			container.MustResolve(&results[i])
+		}()
+	}
+	wg.Wait()
+	for _, svc := range results {
+		if svc != results[0] || svc.Value != "computed from db" {
+			t.Fatal("Expected every resolve to return the same computed value")
+		}
+	}
+	evaluated := calls.Load()
+	var again *TestService
+	container.MustResolve(&again)
+	if again != results[0] || calls.Load() != evaluated {
+		t.Error("Expected the cached value without another evaluation")
+	}
+	container.ClearCaches()
+	container.MustResolve(&again)
+	if again == results[0] || calls.Load() != evaluated+1 {
+		t.Errorf("Expected ClearCaches to recompute the value, got %d evaluations", calls.Load())
+	}
+
+	if err := ContainerRegisterComputed[*TestService](container, nil); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc, got %v", err)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	if err := RegisterComputed(func(c *Container) int { return 42 }); err != nil {
+		t.Fatalf("RegisterComputed failed: %v", err)
+	}
+	if MustGet[int]() != 42 {
+		t.Error("Expected computed int")
+	}
+}
+
+// TestContainerHas tests feature detection over exact, interface, pointer/value and slice cases
+func TestContainerHas(t *testing.T) {
+	container := NewContainer()
+	if ContainerHas[*TestService](container) {
+		t.Error("Expected empty container to have nothing")
+	}
+
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegister(NewTestImpl, Singleton)
+	if !ContainerHas[*TestService](container) {
+		t.Error("Expected exact registration to be found")
+	}
+	// Interface implemented by a registered concrete type
+	if !ContainerHas[ITestInterface](container) || !ContainerHas[[]ITestInterface](container) {
+		t.Error("Expected interface implemented by *TestImpl to be found")
+	}
+	if ContainerHas[io.Reader](container) {
+		t.Error("Expected unimplemented interface not to be found")
+	}
+
+	// Value requested for a registered pointer: only with auto deref
+	if ContainerHas[TestService](container) {
+		t.Error("Expected value type not to be found without auto deref")
+	}
+	container.SetAutoDeref(true)
+	if !ContainerHas[TestService](container) {
+		t.Error("Expected value type to be found with auto deref")
+	}
+	// Pointer requested for a registered value singleton is always adaptable
+	container.MustRegisterInstance(TestDependency{Name: "value"}, Singleton)
+	if !ContainerHas[*TestDependency](container) {
+		t.Error("Expected pointer to value singleton to be found")
+	}
+
+	// Scope overrides count
+	scope := NewContainer().NewScope()
+	if ScopeHas[io.Reader](scope) {
+		t.Error("Expected scope without overrides to have nothing")
+	}
+	if err := scope.OverrideAs(strings.NewReader("x"), (*io.Reader)(nil)); err != nil {
+		t.Fatalf("OverrideAs failed: %v", err)
+	}
+	if !ScopeHas[io.Reader](scope) || !ScopeHas[io.Reader](scope.NewScope()) {
+		t.Error("Expected override to be found in the scope and its children")
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	MustRegister(NewTestService, Singleton)
+	if !Has[*TestService]() || Has[*TestImpl]() {
+		t.Error("Expected Has to check the global container")
+	}
+}
+
+// TestNamedScope tests persistent per-tag scopes: reuse within a tag, isolation across tags and disposal
+func TestNamedScope(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Scoped)
+
+	acme := container.NamedScope("tenant:acme")
+	if container.NamedScope("tenant:acme") != acme {
+		t.Fatal("Expected the same scope for the same tag")
+	}
+	var acme1, acme2, globex *TestService
+	acme.MustResolve(&acme1)
+	container.NamedScope("tenant:acme").MustResolve(&acme2)
+	container.NamedScope("tenant:globex").MustResolve(&globex)
+	if acme1 != acme2 {
+		t.Error("Expected Scoped instance reuse within a tag")
+	}
+	if acme1 == globex {
+		t.Error("Expected isolation between tags")
+	}
+
+	if ok, err := container.DisposeNamedScope("tenant:acme"); !ok || err != nil {
+		t.Error("Expected DisposeNamedScope to report an existing scope")
+	}
+	if ok, _ := container.DisposeNamedScope("tenant:acme"); ok {
+		t.Error("Expected second DisposeNamedScope to report nothing to dispose")
+	}
+	var fresh *TestService
+	container.NamedScope("tenant:acme").MustResolve(&fresh)
+	if fresh == acme1 {
+		t.Error("Expected a fresh scope after disposal")
+	}
+	var stillGlobex *TestService
+	container.NamedScope("tenant:globex").MustResolve(&stillGlobex)
+	if stillGlobex != globex {
+		t.Error("Expected other tags to be unaffected by disposal")
+	}
+}
+
+// TestRegisterImplementation tests multiple unnamed implementations of one interface and single-resolve ambiguity
+func TestRegisterImplementation(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton)
+
+	// A single implementation resolves
+	var single ITestInterface
+	if err := container.Resolve(&single); err != nil || single.GetValue() != "impl" {
+		t.Fatalf("Expected the only implementation, got %v", err)
+	}
+
+	container.MustRegisterInstanceImplementation(TestImplB{}, (*ITestInterface)(nil), Singleton)
+
+	// Several implementations: single resolution is ambiguous
+	var ambiguous ITestInterface
+	if err := container.Resolve(&ambiguous); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution, got %v", err)
+	}
+	if _, err := ScopeGet[ITestInterface](container.NewScope()); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution in scope, got %v", err)
+	}
+
+	// Collections return every implementation in registration order
+	var all []ITestInterface
+	if err := container.ResolveAll(&all); err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+	if len(all) != 2 || all[0].GetValue() != "impl" || all[1].GetValue() != "implB" {
+		t.Errorf("Expected both implementations, got %v", all)
+	}
+	type Aggregator struct{ Impls []ITestInterface }
+	container.MustRegister(func(impls []ITestInterface) *Aggregator { return &Aggregator{Impls: impls} }, Transient)
+	var agg *Aggregator
+	container.MustResolve(&agg)
+	if len(agg.Impls) != 2 {
+		t.Errorf("Expected slice injection of both implementations, got %d", len(agg.Impls))
+	}
+
+	// A RegisterAs registration takes precedence for single resolution and is collected as well
+	container.MustRegisterInstanceAs(&TestImpl{Value: "default"}, (*ITestInterface)(nil), Singleton)
+	var preferred ITestInterface
+	container.MustResolve(&preferred)
+	if preferred.GetValue() != "default" {
+		t.Errorf("Expected RegisterAs registration to win, got %s", preferred.GetValue())
+	}
+	container.MustResolveAll(&all)
+	if len(all) != 3 {
+		t.Errorf("Expected 3 services, got %d", len(all))
+	}
+
+	// Only interface targets are accepted
+	if err := container.RegisterImplementation(NewTestImpl, nil, Singleton); !errors.Is(err, ErrInvalidInterfaceType) {
+		t.Errorf("Expected ErrInvalidInterfaceType, got %v", err)
+	}
+	if err := container.RegisterInstanceImplementation(TestImplB{}, (*ITestInterface)(nil), Transient); !errors.Is(err, ErrTransientInstance) {
+		t.Errorf("Expected ErrTransientInstance, got %v", err)
+	}
+}
+
+// TestGetSliceIncludesImplementations tests that Get[[]Iface] collects additional implementations
+func TestGetSliceIncludesImplementations(t *testing.T) {
+	GlobalReset()
+	defer GlobalReset()
+	Global.MustRegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton)
+	Global.MustRegisterImplementation(func() TestImplB { return TestImplB{} }, (*ITestInterface)(nil), Transient)
+	if impls := MustGet[[]ITestInterface](); len(impls) != 2 {
+		t.Errorf("Expected 2 implementations, got %d", len(impls))
+	}
+}
+
+// TestAmbiguousResolutionListsCandidates tests that the ambiguity error names every candidate type
+func TestAmbiguousResolutionListsCandidates(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstanceImplementation(TestImplB{}, (*ITestInterface)(nil), Singleton)
+
+	type Consumer struct{ Impl ITestInterface }
+	container.MustRegister(func(impl ITestInterface) *Consumer { return &Consumer{Impl: impl} }, Transient)
+	var consumer *Consumer
+	err := container.Resolve(&consumer)
+	if !errors.Is(err, ErrAmbiguousResolution) {
+		t.Fatalf("Expected ErrAmbiguousResolution through a dependency, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "candidates: [*gofac.TestImpl, gofac.TestImplB]") {
+		t.Errorf("Expected candidate types in the error, got %v", err)
+	}
+}
+
+// TestPrimaryImplementation tests that the primary implementation wins single resolution while collections return all
+func TestPrimaryImplementation(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstanceImplementation(TestImplB{}, (*ITestInterface)(nil), Singleton, Primary())
+	container.MustRegisterImplementation(func() *TestImpl { return &TestImpl{Value: "third"} }, (*ITestInterface)(nil), Transient)
+
+	var single ITestInterface
+	if err := container.Resolve(&single); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if single.GetValue() != "implB" {
+		t.Errorf("Expected primary implementation, got %s", single.GetValue())
+	}
+	if got := ScopeMustGet[ITestInterface](container.NewScope()); got.GetValue() != "implB" {
+		t.Errorf("Expected primary implementation in scope, got %s", got.GetValue())
+	}
+
+	var all []ITestInterface
+	container.MustResolveAll(&all)
+	if len(all) != 3 {
+		t.Errorf("Expected all 3 implementations, got %d", len(all))
+	}
+
+	// A second primary for the same interface is rejected
+	err := container.RegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton, Primary())
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate for second primary, got %v", err)
+	}
+
+	// RegisterAs takes registration options too and shares the single primary with the implementations
+	ordered := NewContainer()
+	ordered.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton, Primary(), WithPriority(10))
+	ordered.MustRegisterInstanceImplementation(TestImplB{}, (*ITestInterface)(nil), Singleton)
+	var ranked []ITestInterface
+	if err := ordered.ResolveAllOpts(&ranked, ResolveAllOptions{Ordered: true, IncludeDefault: true, IncludeConstructors: true}); err != nil {
+		t.Fatalf("ResolveAllOpts failed: %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].GetValue() != "implB" {
+		t.Errorf("Expected WithPriority to order the RegisterAs registration last, got %v", ranked)
+	}
+	err = ordered.RegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton, Primary())
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate for a primary besides a primary RegisterAs, got %v", err)
+	}
+
+	// A RegisterAs registration and implementations without a primary are ambiguous
+	mixed := NewContainer()
+	mixed.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton)
+	mixed.MustRegisterImplementation(func() TestImplB { return TestImplB{} }, (*ITestInterface)(nil), Singleton)
+	if _, err := ResolverGet[ITestInterface](mixed); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution for RegisterAs plus an implementation, got %v", err)
+	}
+	if _, err := ScopeGet[ITestInterface](mixed.NewScope()); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution in scope, got %v", err)
+	}
+
+	// A primary implementation wins over a non-primary RegisterAs registration
+	mixed = NewContainer()
+	mixed.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton)
+	mixed.MustRegisterImplementation(func() TestImplB { return TestImplB{} }, (*ITestInterface)(nil), Singleton, Primary())
+	if got := ResolverMustGet[ITestInterface](mixed); got.GetValue() != "implB" {
+		t.Errorf("Expected the primary implementation over RegisterAs, got %s", got.GetValue())
+	}
+	if got := ScopeMustGet[ITestInterface](mixed.NewScope()); got.GetValue() != "implB" {
+		t.Errorf("Expected the primary implementation over RegisterAs in scope, got %s", got.GetValue())
+	}
+}
+
+// TestConcurrentRegisterResolveStress registers, resolves and collects concurrently; run with -race to detect data races
+func TestConcurrentRegisterResolveStress(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Singleton)
+	container.MustRegister(NewTestService, Scoped)
+	container.MustRegisterNamed("lazy", func() *TestImpl { return &TestImpl{Value: "lazy"} }, Singleton)
+
+	const workers = 8
+	const iterations = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*iterations*6)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				name := "svc-" + strconv.Itoa(w) + "-" + strconv.Itoa(i)
+				errs <- container.RegisterInstanceNamed(name, &TestService{Value: name}, Singleton)
+				errs <- container.RegisterImplementation(NewTestImpl, (*ITestInterface)(nil), Singleton)
+
+				var svc *TestServiceWithDep
+				errs <- container.Resolve(&svc)
+				var impl *TestImpl
+				errs <- container.ResolveNamed("lazy", &impl)
+				var all []*TestService
+				errs <- container.ResolveAll(&all)
+				var scoped *TestService
+				errs <- container.NewScope().Resolve(&scoped)
+				var impls []ITestInterface
+				_ = container.ResolveAll(&impls)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Concurrent operation failed: %v", err)
+		}
+	}
+
+	var all []*TestService
+	container.MustResolveAll(&all)
+	if len(all) != workers*iterations {
+		t.Errorf("Expected %d named instances, got %d", workers*iterations, len(all))
+	}
+	var a, b *TestServiceWithDep
+	container.MustResolve(&a)
+	container.MustResolve(&b)
+	if a != b {
+		t.Error("Expected a single singleton instance after concurrent resolution")
+	}
+}
+
+// TestConstructorPanicBecomesError tests that panicking constructors, factories and init methods yield ErrCreateInstanceFailed
+func TestConstructorPanicBecomesError(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func() *TestDependency {
+		var m map[string]int
+		m["boom"] = 1 // nil map write
+		return &TestDependency{}
+	}, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+
+	var svc *TestServiceWithDep
+	err := container.Resolve(&svc)
+	if !errors.Is(err, ErrCreateInstanceFailed) {
+		t.Fatalf("Expected ErrCreateInstanceFailed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "*gofac.TestDependency panicked") || !strings.Contains(err.Error(), "nil map") {
+		t.Errorf("Expected error naming the type and panic value, got %v", err)
+	}
+
+	// Resolution state is left clean: a later resolve reports the same error rather than a circular dependency
+	if err := container.Resolve(&svc); errors.Is(err, ErrResolveCircularDependency) || !errors.Is(err, ErrCreateInstanceFailed) {
+		t.Errorf("Expected ErrCreateInstanceFailed again, got %v", err)
+	}
+
+	// Factories and scope resolution are covered as well
+	if err := ContainerRegisterFactory(container, func(c *Container) (*TestService, error) { panic("factory boom") }, Scoped); err != nil {
+		t.Fatalf("ContainerRegisterFactory failed: %v", err)
+	}
+	var scoped *TestService
+	if err := container.NewScope().Resolve(&scoped); !errors.Is(err, ErrCreateInstanceFailed) || !strings.Contains(err.Error(), "factory boom") {
+		t.Errorf("Expected ErrCreateInstanceFailed from panicking factory, got %v", err)
+	}
+}
+
+// TestResolveAllUnique tests deduplication of one instance registered several times
+func TestResolveAllUnique(t *testing.T) {
+	container := NewContainer()
+	shared := &TestService{Value: "shared"}
+	other := &TestService{Value: "other"}
+	container.MustRegisterInstance(shared, Singleton)
+	container.MustRegisterInstanceNamed("primary", shared, Singleton)
+	container.MustRegisterInstanceNamed("alias", shared, Singleton)
+	container.MustRegisterInstanceNamed("other", other, Singleton)
+
+	// Default behavior keeps duplicates
+	var all []*TestService
+	container.MustResolveAll(&all)
+	if len(all) != 4 {
+		t.Fatalf("Expected 4 services without dedup, got %d", len(all))
+	}
+
+	var unique []*TestService
+	if err := container.ResolveAllUnique(&unique); err != nil {
+		t.Fatalf("ResolveAllUnique failed: %v", err)
+	}
+	if len(unique) != 2 || unique[0] != shared || unique[1] != other {
+		t.Errorf("Expected [shared other], got %v", unique)
+	}
+
+	// Interface elements are deduplicated by their dynamic value
+	impl := NewTestImpl()
+	container.MustRegisterInstanceAsNamed("a", impl, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstanceAsNamed("b", impl, (*ITestInterface)(nil), Singleton)
+	var impls []ITestInterface
+	container.MustResolveAllUnique(&impls)
+	if len(impls) != 1 {
+		t.Errorf("Expected a single interface instance, got %d", len(impls))
+	}
+
+	if err := container.ResolveAllUnique(&TestService{}); err == nil {
+		t.Error("Expected error for non-slice output")
+	}
+}
+
+type ICacheReader interface {
+	Get(key string) string
+}
+
+type ViewCache struct {
+	data map[string]string
+}
+
+func (c *ViewCache) Get(key string) string { return c.data[key] }
+func (c *ViewCache) Set(key, value string) { c.data[key] = value }
+func NewViewCache() *ViewCache             { return &ViewCache{data: map[string]string{"k": "v"}} }
+
+// TestResolveAsView tests resolution-time upcasting of a concrete registration to a narrow interface
+func TestResolveAsView(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewViewCache, Singleton)
+
+	reader, err := ContainerResolveAsView[ICacheReader](container)
+	if err != nil {
+		t.Fatalf("ContainerResolveAsView failed: %v", err)
+	}
+	var cache *ViewCache
+	container.MustResolve(&cache)
+	if reader.Get("k") != "v" || reader.(*ViewCache) != cache {
+		t.Error("Expected the registered singleton typed as the view")
+	}
+	if container.isRegistered(reflect.TypeOf((*ICacheReader)(nil)).Elem()) {
+		t.Error("Expected no registration under the view type")
+	}
+
+	// No implementing registration
+	if _, err := ContainerResolveAsView[io.Reader](container); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+	// Several implementing registrations
+	container.MustRegister(func() *TestImpl { return &TestImpl{} }, Singleton)
+	container.MustRegister(func() TestImplB { return TestImplB{} }, Singleton)
+	if _, err := ContainerResolveAsView[ITestInterface](container); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution, got %v", err)
+	}
+	// View must be an interface
+	if _, err := ContainerResolveAsView[*ViewCache](container); !errors.Is(err, ErrInvalidInterfaceType) {
+		t.Errorf("Expected ErrInvalidInterfaceType, got %v", err)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	MustRegister(NewViewCache, Singleton)
+	if reader, err := ResolveAsView[ICacheReader](); err != nil || reader.Get("k") != "v" {
+		t.Errorf("Expected global view resolution, got %v", err)
+	}
+}
+
+// TestEmptyAutoCollection tests the empty-match semantics of slice and map auto-injection
+func TestEmptyAutoCollection(t *testing.T) {
+	type Consumer struct {
+		Services []*TestService
+		ByName   map[string]*TestService
+	}
+	newConsumer := func(services []*TestService, byName map[string]*TestService) *Consumer {
+		return &Consumer{Services: services, ByName: byName}
+	}
+
+	// Default: empty non-nil slice and map
+	container := NewContainer()
+	container.MustRegister(newConsumer, Transient)
+	var consumer *Consumer
+	container.MustResolve(&consumer)
+	if consumer.Services == nil || len(consumer.Services) != 0 {
+		t.Errorf("Expected empty non-nil slice by default, got %#v", consumer.Services)
+	}
+	if consumer.ByName == nil || len(consumer.ByName) != 0 {
+		t.Errorf("Expected empty non-nil map, got %#v", consumer.ByName)
+	}
+
+	// Nil empty slices: slice is nil, map stays non-nil (root container and scope)
+	container.SetNilEmptySlices(true)
+	container.MustResolve(&consumer)
+	if consumer.Services != nil {
+		t.Errorf("Expected nil slice, got %#v", consumer.Services)
+	}
+	if consumer.ByName == nil {
+		t.Error("Expected map to stay non-nil")
+	}
+	container.NewScope().MustResolve(&consumer)
+	if consumer.Services != nil {
+		t.Errorf("Expected nil slice in scope, got %#v", consumer.Services)
+	}
+
+	// Matches are unaffected by the setting
+	container.MustRegisterInstanceNamed("a", &TestService{}, Singleton)
+	container.MustResolve(&consumer)
+	if len(consumer.Services) != 1 {
+		t.Errorf("Expected 1 collected service, got %d", len(consumer.Services))
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	if impls := MustGet[[]ITestInterface](); impls == nil {
+		t.Error("Expected empty non-nil slice from Get by default")
+	}
+	Global.SetNilEmptySlices(true)
+	if impls := MustGet[[]ITestInterface](); impls != nil {
+		t.Errorf("Expected nil slice from Get, got %#v", impls)
+	}
+}
+
+// TestArrayAutoCollection tests fixed-size array parameters collected from registered services
+func TestArrayAutoCollection(t *testing.T) {
+	type Pool struct{ Members [2]*TestService }
+	newPool := func(members [2]*TestService) *Pool { return &Pool{Members: members} }
+
+	container := NewContainer()
+	container.MustRegisterInstance(&TestService{Value: "default"}, Singleton)
+	container.MustRegisterInstanceNamed("replica", &TestService{Value: "replica"}, Singleton)
+	container.MustRegister(newPool, Transient)
+
+	var pool *Pool
+	if err := container.Resolve(&pool); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if pool.Members[0].Value != "default" || pool.Members[1].Value != "replica" {
+		t.Errorf("Expected default then named service, got %v, %v", pool.Members[0], pool.Members[1])
+	}
+	if err := container.NewScope().Resolve(&pool); err != nil {
+		t.Errorf("Scope resolve failed: %v", err)
+	}
+
+	// Count mismatch
+	container.MustRegisterInstanceNamed("extra", &TestService{Value: "extra"}, Singleton)
+	if err := container.Resolve(&pool); !errors.Is(err, ErrArrayLengthMismatch) {
+		t.Errorf("Expected ErrArrayLengthMismatch, got %v", err)
+	}
+	if err := container.NewScope().Resolve(&pool); !errors.Is(err, ErrArrayLengthMismatch) {
+		t.Errorf("Expected ErrArrayLengthMismatch in scope, got %v", err)
+	}
+
+	// A registered array type is injected as is
+	type Priorities struct{ Values [5]int }
+	container = NewContainer()
+	container.MustRegisterInstance([5]int{1, 2, 3, 4, 5}, Singleton)
+	container.MustRegister(func(v [5]int) *Priorities { return &Priorities{Values: v} }, Transient)
+	var priorities *Priorities
+	container.MustResolve(&priorities)
+	if priorities.Values[4] != 5 {
+		t.Errorf("Expected registered array, got %v", priorities.Values)
+	}
+}
+
+// TestParamTypes tests inspecting a registered constructor's declared dependencies
+func TestParamTypes(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestServiceWithDep, Singleton)
+	container.MustRegisterInstance(&TestService{}, Singleton)
+	if err := ContainerRegisterFactory(container, func(c *Container) (*TestImpl, error) { return NewTestImpl(), nil }, Singleton); err != nil {
+		t.Fatalf("ContainerRegisterFactory failed: %v", err)
+	}
+
+	params, err := container.ParamTypes(reflect.TypeOf(&TestServiceWithDep{}))
+	if err != nil {
+		t.Fatalf("ParamTypes failed: %v", err)
+	}
+	if len(params) != 1 || params[0] != reflect.TypeOf(&TestDependency{}) {
+		t.Errorf("Expected [*TestDependency], got %v", params)
+	}
+	// The result is a copy
+	params[0] = nil
+	if again, _ := container.ParamTypes(reflect.TypeOf(&TestServiceWithDep{})); again[0] == nil {
+		t.Error("Expected ParamTypes to return a copy of the cached metadata")
+	}
+
+	if _, err := container.ParamTypes(reflect.TypeOf(&TestService{})); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc for instance registration, got %v", err)
+	}
+	if _, err := container.ParamTypes(reflect.TypeOf(&TestImpl{})); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc for factory registration, got %v", err)
+	}
+	if _, err := container.ParamTypes(reflect.TypeOf(&TestDependency{})); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+// TestSetFallback tests the fallback provider for unregistered types
+func TestSetFallback(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestServiceWithDep, Transient)
+
+	var dep *TestDependency
+	if err := container.Resolve(&dep); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("Expected ErrServiceNotRegistered without fallback, got %v", err)
+	}
+
+	var asked []reflect.Type
+	container.SetFallback(func(t reflect.Type) (reflect.Value, bool, error) {
+		asked = append(asked, t)
+		if t == reflect.TypeOf(&TestDependency{}) {
+			return reflect.ValueOf(&TestDependency{Name: "external"}), true, nil
+		}
+		return reflect.Value{}, false, nil
+	})
+
+	// Direct resolution and as a constructor dependency, in root and scope
+	container.MustResolve(&dep)
+	var svc *TestServiceWithDep
+	container.NewScope().MustResolve(&svc)
+	if dep.Name != "external" || svc.Dep.Name != "external" {
+		t.Error("Expected fallback to supply the unregistered dependency")
+	}
+
+	// Declined types still fail as not registered
+	var other *TestService
+	if err := container.Resolve(&other); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered for declined type, got %v", err)
+	}
+	if asked[len(asked)-1] != reflect.TypeOf(other) {
+		t.Error("Expected fallback to be consulted for the declined type")
+	}
+
+	// Fallback errors and mismatched values are reported
+	boom := errors.New("boom")
+	container.SetFallback(func(t reflect.Type) (reflect.Value, bool, error) { return reflect.Value{}, false, boom })
+	if err := container.Resolve(&other); !errors.Is(err, boom) {
+		t.Errorf("Expected fallback error, got %v", err)
+	}
+	container.SetFallback(func(t reflect.Type) (reflect.Value, bool, error) { return reflect.ValueOf(42), true, nil })
+	if err := container.Resolve(&other); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed, got %v", err)
+	}
+
+	// Registered services never reach the fallback
+	container.MustRegister(NewTestService, Singleton)
+	if err := container.Resolve(&other); err != nil {
+		t.Errorf("Expected registered service to resolve, got %v", err)
+	}
+}
+
+// tracingProxy Wraps an ITestInterface by convention in TestOnResolveTransform
+type tracingProxy struct {
+	inner ITestInterface
+	calls *int
+}
+
+func (p *tracingProxy) GetValue() string {
+	*p.calls++
+	return p.inner.GetValue()
+}
+
+// TestOnResolveTransform tests resolve transforms: ordering, cache-hit flag, dependencies and type checks
+func TestOnResolveTransform(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton)
+	container.MustRegister(func(i ITestInterface) *TestService { return &TestService{Value: i.GetValue()} }, Transient)
+
+	traced := 0
+	ifaceType := reflect.TypeOf((*ITestInterface)(nil)).Elem()
+	var order []string
+	container.OnResolveTransform(func(t reflect.Type, v reflect.Value) reflect.Value {
+		order = append(order, "proxy")
+		if t == ifaceType {
+			return reflect.ValueOf(&tracingProxy{inner: v.Interface().(ITestInterface), calls: &traced})
+		}
+		return v
+	}, true)
+	freshOnly := 0
+	container.OnResolveTransform(func(t reflect.Type, v reflect.Value) reflect.Value {
+		order = append(order, "fresh")
+		if t == ifaceType {
+			freshOnly++
+			if _, ok := v.Interface().(*tracingProxy); !ok {
+				panic("expected transforms to run in registration order")
+			}
+		}
+		return v
+	}, false)
+
+	// First resolution constructs: both transforms run, in order
+	var first ITestInterface
+	container.MustResolve(&first)
+	if strings.Join(order, ",") != "proxy,fresh" {
+		t.Errorf("Expected transforms in registration order, got %v", order)
+	}
+	if first.GetValue() != "impl" || traced != 1 {
+		t.Error("Expected the resolved value to be the tracing proxy")
+	}
+
+	// Cache hit: only the cacheHits transform runs; the cached value itself stays unwrapped
+	var second ITestInterface
+	container.MustResolve(&second)
+	if freshOnly != 1 {
+		t.Errorf("Expected fresh-only transform to skip cache hits, ran %d times", freshOnly)
+	}
+	if proxy, ok := second.(*tracingProxy); !ok || proxy.inner != first.(*tracingProxy).inner {
+		t.Error("Expected cache hits to be wrapped around the same cached instance")
+	}
+
+	// Constructor dependencies and scopes are transformed too
+	var svc *TestService
+	container.MustResolve(&svc)
+	container.NewScope().MustResolve(&svc)
+	if traced != 3 {
+		t.Errorf("Expected dependency to be resolved through the proxy, got %d traced calls", traced)
+	}
+
+	// Results must stay assignable to the resolved type
+	container.OnResolveTransform(func(t reflect.Type, v reflect.Value) reflect.Value { return reflect.ValueOf(42) }, true)
+	if err := container.Resolve(&second); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed, got %v", err)
+	}
+}
+
+// TestImplementsScan tests resolving an unregistered interface from the single concrete registration implementing it
+func TestImplementsScan(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestImpl, Singleton)
+	container.MustRegister(func(i ITestInterface) *TestService { return &TestService{Value: i.GetValue()} }, Transient)
+
+	var iface ITestInterface
+	if err := container.Resolve(&iface); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("Expected ErrServiceNotRegistered without the scan, got %v", err)
+	}
+
+	container.EnableImplementsScan(true)
+	container.MustResolve(&iface)
+	var impl *TestImpl
+	container.MustResolve(&impl)
+	if iface != impl {
+		t.Error("Expected the interface to resolve to the registered singleton")
+	}
+	// Also as a dependency, in a scope and through ScopeGet
+	var svc *TestService
+	container.NewScope().MustResolve(&svc)
+	if svc.Value != "impl" {
+		t.Errorf("Expected dependency resolved by the scan, got %q", svc.Value)
+	}
+	if got, err := ScopeGet[ITestInterface](container.NewScope()); err != nil || got != iface {
+		t.Errorf("Expected ScopeGet to use the scan, got %v (%v)", got, err)
+	}
+
+	// Several implementing registrations are ambiguous
+	container.MustRegisterInstance(TestImplB{}, Singleton)
+	err := container.Resolve(&iface)
+	if !errors.Is(err, ErrAmbiguousResolution) || !strings.Contains(err.Error(), "gofac.TestImplB") {
+		t.Errorf("Expected ErrAmbiguousResolution listing candidates, got %v", err)
+	}
+}
+
+// TestInvalidate tests rebuilding a single Singleton on the next resolution
+func TestInvalidate(t *testing.T) {
+	container := NewContainer()
+	builds := 0
+	container.MustRegister(func() *TestDependency {
+		builds++
+		return &TestDependency{Name: "config-" + strconv.Itoa(builds)}
+	}, Singleton)
+	container.MustRegister(NewTestService, Singleton)
+
+	var first, again *TestDependency
+	container.MustResolve(&first)
+	container.MustResolve(&again)
+	var svc *TestService
+	container.MustResolve(&svc)
+	if first != again {
+		t.Fatal("Expected singleton before invalidation")
+	}
+
+	if err := container.Invalidate(reflect.TypeOf(first)); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+	var rebuilt *TestDependency
+	container.MustResolve(&rebuilt)
+	if rebuilt == first || rebuilt.Name != "config-2" {
+		t.Errorf("Expected a new instance after invalidation, got %v", rebuilt)
+	}
+	var rebuiltAgain *TestDependency
+	container.MustResolve(&rebuiltAgain)
+	var svcAgain *TestService
+	container.MustResolve(&svcAgain)
+	if rebuiltAgain != rebuilt || svcAgain != svc {
+		t.Error("Expected the rebuilt singleton to be cached and other singletons untouched")
+	}
+
+	// Instances, other lifetimes and unknown types cannot be invalidated
+	container.MustRegisterInstance(&TestImpl{}, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	if err := ContainerInvalidate[*TestImpl](container); !errors.Is(err, ErrInvalidateNotSupported) {
+		t.Errorf("Expected ErrInvalidateNotSupported for an instance, got %v", err)
+	}
+	if err := ContainerInvalidate[*TestServiceWithDep](container); !errors.Is(err, ErrInvalidateNotSupported) {
+		t.Errorf("Expected ErrInvalidateNotSupported for a Transient, got %v", err)
+	}
+	if err := ContainerInvalidate[ITestInterface](container); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	MustRegister(NewTestDependency, Singleton)
+	before := MustGet[*TestDependency]()
+	if err := Invalidate[*TestDependency](); err != nil || MustGet[*TestDependency]() == before {
+		t.Errorf("Expected global Invalidate to rebuild the singleton (err %v)", err)
+	}
+}
+
+// RequestUser A request value bound to a scope in TestScopeBindValue
+type RequestUser struct{ Name string }
+
+type UserHandler struct{ User *RequestUser }
+
+// TestScopeBindValue tests binding request values to a scope as typed dependencies
+func TestScopeBindValue(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func(u *RequestUser) *UserHandler { return &UserHandler{User: u} }, Scoped)
+
+	alice := container.NewScope()
+	if err := alice.BindValue(&RequestUser{Name: "alice"}); err != nil {
+		t.Fatalf("BindValue failed: %v", err)
+	}
+	var handler *UserHandler
+	alice.MustResolve(&handler)
+	if handler.User.Name != "alice" {
+		t.Errorf("Expected handler for alice, got %q", handler.User.Name)
+	}
+	if !ScopeHas[*RequestUser](alice) {
+		t.Error("Expected ScopeHas to report the bound value")
+	}
+
+	// Nested scopes see the value; other scopes and the root do not
+	var nested *RequestUser
+	alice.NewScope().MustResolve(&nested)
+	if nested.Name != "alice" {
+		t.Error("Expected nested scope to inherit the bound value")
+	}
+	var other *UserHandler
+	if err := container.NewScope().Resolve(&other); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered in an unbound scope, got %v", err)
+	}
+
+	// Registered Scoped types are pre-filled, other lifetimes and nil are rejected
+	container.MustRegister(NewTestDependency, Scoped)
+	container.MustRegister(NewTestService, Singleton)
+	preset := &TestDependency{Name: "bound"}
+	scope := container.NewScope()
+	if err := scope.BindValue(preset); err != nil {
+		t.Fatalf("BindValue of a Scoped type failed: %v", err)
+	}
+	var dep *TestDependency
+	scope.MustResolve(&dep)
+	if dep != preset {
+		t.Error("Expected the bound value to pre-fill the Scoped instance")
+	}
+	if err := scope.BindValue(&TestService{}); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate for a Singleton type, got %v", err)
+	}
+	if err := scope.BindValue(nil); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance, got %v", err)
+	}
+}
+
+// TestChildContainerResolveAll tests child containers resolving from and collecting across the parent chain
+func TestChildContainerResolveAll(t *testing.T) {
+	parent := NewContainer()
+	base := &TestService{Value: "base"}
+	shared := &TestService{Value: "shared"}
+	parent.MustRegisterInstanceNamed("base", base, Singleton)
+	parent.MustRegisterInstanceNamed("shared", shared, Singleton)
+	parent.MustRegister(NewTestDependency, Singleton)
+
+	child := parent.NewChild()
+	plugin := &TestService{Value: "plugin"}
+	child.MustRegisterInstanceNamed("plugin", plugin, Singleton)
+	child.MustRegisterInstanceNamed("shared", shared, Singleton)
+	child.MustRegister(NewTestServiceWithDep, Transient)
+
+	// Unregistered types resolve from the parent, cached there
+	var withDep *TestServiceWithDep
+	child.MustResolve(&withDep)
+	var parentDep *TestDependency
+	parent.MustResolve(&parentDep)
+	if withDep.Dep != parentDep {
+		t.Error("Expected the child to resolve the parent's singleton")
+	}
+
+	// Without the option only the child's own services
+	var own []*TestService
+	child.MustResolveAll(&own)
+	if len(own) != 2 {
+		t.Fatalf("Expected 2 child services, got %d", len(own))
+	}
+
+	// Child first, then parents, deduplicated by pointer
+	var all []*TestService
+	child.MustResolveAll(&all, IncludeParents())
+	values := make([]string, len(all))
+	for i, svc := range all {
+		values[i] = svc.Value
+	}
+	if strings.Join(values, ",") != "plugin,shared,base" {
+		t.Errorf("Expected child-first deduplicated order, got %v", values)
+	}
+
+	// Grandchildren walk the whole chain; the parent never sees the child
+	var chain []*TestService
+	child.NewChild().MustResolveAll(&chain, IncludeParents())
+	if len(chain) != 3 {
+		t.Errorf("Expected 3 services across the chain, got %d", len(chain))
+	}
+	var fromParent []*TestService
+	parent.MustResolveAll(&fromParent, IncludeParents())
+	if len(fromParent) != 2 {
+		t.Errorf("Expected the parent to only see its own services, got %d", len(fromParent))
+	}
+	var missing *TestImpl
+	if err := child.Resolve(&missing); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered across the chain, got %v", err)
+	}
+}
+
+// TestInterfaceResolutionDiagnostics tests errors distinguishing nil, unimplemented and unexposed interfaces
+func TestInterfaceResolutionDiagnostics(t *testing.T) {
+	// Registered but nil: directly and as a dependency
+	container := NewContainer()
+	container.MustRegisterAs(func() ITestInterface { return nil }, (*ITestInterface)(nil), Singleton)
+	container.MustRegister(func(i ITestInterface) *TestService { return &TestService{} }, Transient)
+	var iface ITestInterface
+	if err := container.Resolve(&iface); !errors.Is(err, ErrNilInterfaceInstance) {
+		t.Errorf("Expected ErrNilInterfaceInstance, got %v", err)
+	}
+	var svc *TestService
+	if err := container.NewScope().Resolve(&svc); !errors.Is(err, ErrNilInterfaceInstance) {
+		t.Errorf("Expected ErrNilInterfaceInstance for the dependency, got %v", err)
+	}
+
+	// Never registered and nothing implements it
+	container = NewContainer()
+	err := container.Resolve(&iface)
+	if !errors.Is(err, ErrServiceNotRegistered) || !errors.Is(err, ErrNoImplementation) {
+		t.Errorf("Expected ErrServiceNotRegistered and ErrNoImplementation, got %v", err)
+	}
+
+	// Never registered as the interface, but implemented by a registration
+	container.MustRegister(NewTestImpl, Singleton)
+	err = container.Resolve(&iface)
+	if !errors.Is(err, ErrServiceNotRegistered) || errors.Is(err, ErrNoImplementation) || !strings.Contains(err.Error(), "*gofac.TestImpl") {
+		t.Errorf("Expected a hint naming the implementing registration, got %v", err)
+	}
+}
+
+// TestRequireImplementations tests failing auto-collection of an interface slice nothing implements
+func TestRequireImplementations(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func(all []ITestInterface) *TestService { return &TestService{} }, Transient)
+
+	var svc *TestService
+	container.MustResolve(&svc) // empty slice by default
+
+	container.RequireImplementations(true)
+	if err := container.Resolve(&svc); !errors.Is(err, ErrNoImplementation) {
+		t.Errorf("Expected ErrNoImplementation, got %v", err)
+	}
+	if err := container.NewScope().Resolve(&svc); !errors.Is(err, ErrNoImplementation) {
+		t.Errorf("Expected ErrNoImplementation in a scope, got %v", err)
+	}
+
+	container.MustRegisterInstanceAs(NewTestImpl(), (*ITestInterface)(nil), Singleton)
+	if err := container.Resolve(&svc); err != nil {
+		t.Errorf("Expected collection to succeed once implemented, got %v", err)
+	}
+}
+
+// TestScopeResolveNamedScoped tests per-scope-per-name caching of named Scoped constructors
+func TestScopeResolveNamedScoped(t *testing.T) {
+	container := NewContainer()
+	builds := 0
+	newConn := func(name string) func() *TestDependency {
+		return func() *TestDependency {
+			builds++
+			return &TestDependency{Name: name}
+		}
+	}
+	container.MustRegisterNamed("primary", newConn("primary"), Scoped)
+	container.MustRegisterNamed("replica", newConn("replica"), Scoped)
+	container.MustRegister(newConn("default"), Scoped)
+
+	scope := container.NewScope()
+	var p1, p2, r *TestDependency
+	scope.MustResolveNamed("primary", &p1)
+	scope.MustResolveNamed("primary", &p2)
+	scope.MustResolveNamed("replica", &r)
+	if p1 != p2 || p1.Name != "primary" || r.Name != "replica" || builds != 2 {
+		t.Errorf("Expected one instance per name within the scope (builds=%d)", builds)
+	}
+
+	// Isolated from the default registration and across scopes; shared with nested scopes
+	var def *TestDependency
+	scope.MustResolve(&def)
+	if def == p1 {
+		t.Error("Expected the default registration to be cached separately from named ones")
+	}
+	var other, nested *TestDependency
+	container.NewScope().MustResolveNamed("primary", &other)
+	scope.NewScope().MustResolveNamed("primary", &nested)
+	if other == p1 || nested != p1 {
+		t.Error("Expected different instances across scopes and a shared one in nested scopes")
+	}
+
+	// The root container still rejects named Scoped services; unknown names fail
+	var root *TestDependency
+	if err := container.ResolveNamed("primary", &root); !errors.Is(err, ErrScopedOnRootContainer) {
+		t.Errorf("Expected ErrScopedOnRootContainer on the root, got %v", err)
+	}
+	if err := scope.ResolveNamed("missing", &root); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+// TestMergeRegisteredSlices tests concatenating a registered slice with the auto-collected elements, deduplicated
+func TestMergeRegisteredSlices(t *testing.T) {
+	container := NewContainer()
+	shared := &TestImpl{Value: "shared"}
+	listed := &TestImpl{Value: "listed"}
+	named := &TestImpl{Value: "named"}
+	container.MustRegisterInstance([]ITestInterface{listed, shared}, Singleton)
+	container.MustRegisterInstanceAs(shared, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstanceAsNamed("extra", named, (*ITestInterface)(nil), Singleton)
+
+	type holder struct{ all []ITestInterface }
+	container.MustRegister(func(all []ITestInterface) *holder { return &holder{all} }, Transient)
+
+	values := func(all []ITestInterface) []string {
+		out := make([]string, len(all))
+		for i, v := range all {
+			out[i] = v.GetValue()
+		}
+		return out
+	}
+
+	var h *holder
+	container.MustResolve(&h)
+	if got := values(h.all); !reflect.DeepEqual(got, []string{"listed", "shared"}) {
+		t.Errorf("Expected the registered slice as is by default, got %v", got)
+	}
+
+	container.MergeRegisteredSlices(true)
+	want := []string{"listed", "shared", "named"}
+	container.MustResolve(&h)
+	if got := values(h.all); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected registered elements first and duplicates dropped, got %v", got)
+	}
+	if err := container.NewScope().Resolve(&h); err != nil || !reflect.DeepEqual(values(h.all), want) {
+		t.Errorf("Expected the same merge in a scope, got %v (%v)", values(h.all), err)
+	}
+
+	var registered []ITestInterface
+	container.MustResolve(&registered)
+	if len(registered) != 2 {
+		t.Errorf("Expected the registered slice to stay unmodified, got %v", values(registered))
+	}
+}
+
+// TestClearCaches tests that all singletons rebuild while registrations are kept
+func TestClearCaches(t *testing.T) {
+	container := NewContainer()
+	builds := 0
+	container.MustRegister(func() *TestDependency {
+		builds++
+		return &TestDependency{Name: "dep-" + strconv.Itoa(builds)}
+	}, Singleton)
+	container.MustRegisterNamed("named", func() *TestImpl { return &TestImpl{Value: "named"} }, Singleton)
+	instance := &TestImpl{Value: "instance"}
+	container.MustRegisterInstance(instance, Singleton)
+
+	var dep *TestDependency
+	var named *TestImpl
+	container.MustResolve(&dep)
+	container.MustResolveNamed("named", &named)
+
+	container.ClearCaches()
+
+	var depAgain, depThird *TestDependency
+	var namedAgain, instanceAgain *TestImpl
+	container.MustResolve(&depAgain)
+	container.MustResolve(&depThird)
+	container.MustResolveNamed("named", &namedAgain)
+	container.MustResolve(&instanceAgain)
+	if depAgain == dep || depAgain != depThird || builds != 2 {
+		t.Errorf("Expected the singleton to be rebuilt once, got %v after %d builds", depAgain, builds)
+	}
+	if namedAgain == named {
+		t.Error("Expected the named singleton to be rebuilt")
+	}
+	if instanceAgain != instance {
+		t.Error("Expected the registered instance to be kept")
+	}
+}
+
+// TestSetMapDefaultKey tests including the default registration in auto-collected maps
+func TestSetMapDefaultKey(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceAs(&TestImpl{Value: "default"}, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstanceAsNamed("redis", &TestImpl{Value: "redis"}, (*ITestInterface)(nil), Singleton)
+
+	type holder struct{ caches map[string]ITestInterface }
+	container.MustRegister(func(caches map[string]ITestInterface) *holder { return &holder{caches} }, Transient)
+
+	var h *holder
+	container.MustResolve(&h)
+	if len(h.caches) != 1 || h.caches["redis"] == nil {
+		t.Errorf("Expected only named services by default, got %v", h.caches)
+	}
+
+	container.SetMapDefaultKey(DefaultMapKey, true)
+	container.MustResolve(&h)
+	if len(h.caches) != 2 || h.caches[DefaultMapKey].GetValue() != "default" || h.caches["redis"].GetValue() != "redis" {
+		t.Errorf("Expected default and named services, got %v", h.caches)
+	}
+
+	container.SetMapDefaultKey("", true)
+	if err := container.NewScope().Resolve(&h); err != nil || h.caches[""] == nil || len(h.caches) != 2 {
+		t.Errorf("Expected the default under the empty key in a scope, got %v (%v)", h.caches, err)
+	}
+
+	// Disabling restores named-only maps, in the container and in a scope
+	container.SetMapDefaultKey(DefaultMapKey, false)
+	container.MustResolve(&h)
+	if len(h.caches) != 1 || h.caches["redis"] == nil {
+		t.Errorf("Expected only named services once disabled, got %v", h.caches)
+	}
+	if err := container.NewScope().Resolve(&h); err != nil || len(h.caches) != 1 {
+		t.Errorf("Expected only named services in a scope once disabled, got %v (%v)", h.caches, err)
+	}
+}
+
+// TestResolveContextTimeout tests that an expired deadline is reported as ErrResolveCanceled, not a construction failure
+func TestResolveContextTimeout(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	ctx, cancel := context.WithTimeout(context.Background(), 0) // already expired
+	defer cancel()
+
+	var dep *TestDependency
+	err := container.ResolveContext(ctx, &dep)
+	if !errors.Is(err, ErrResolveCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected ErrResolveCanceled wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if errors.Is(err, ErrCreateInstanceFailed) || errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected cancellation to be distinguishable, got %v", err)
+	}
+}
+
+// TestRegisterDynamic tests a lifetime chosen per resolution
+func TestRegisterDynamic(t *testing.T) {
+	container := NewContainer()
+	lifetime := Singleton
+	builds := 0
+	container.MustRegisterDynamic(func() *TestDependency {
+		builds++
+		return &TestDependency{Name: "dep-" + strconv.Itoa(builds)}
+	}, func() LifetimeScope { return lifetime })
+
+	var a, b *TestDependency
+	container.MustResolve(&a)
+	container.MustResolve(&b)
+	if a != b || builds != 1 {
+		t.Errorf("Expected a singleton while the function yields Singleton, got %d builds", builds)
+	}
+
+	lifetime = Transient
+	container.MustResolve(&a)
+	container.MustResolve(&b)
+	if a == b || builds != 3 {
+		t.Errorf("Expected new instances while the function yields Transient, got %d builds", builds)
+	}
+
+	// Switching back returns the instance cached earlier (no un-caching)
+	lifetime = Singleton
+	var cached *TestDependency
+	container.MustResolve(&cached)
+	if cached.Name != "dep-1" {
+		t.Errorf("Expected the originally cached singleton, got %s", cached.Name)
+	}
+
+	lifetime = Scoped
+	if err := container.Resolve(&a); !errors.Is(err, ErrScopedOnRootContainer) {
+		t.Errorf("Expected ErrScopedOnRootContainer, got %v", err)
+	}
+	scope := container.NewScope()
+	scope.MustResolve(&a)
+	scope.MustResolve(&b)
+	var other *TestDependency
+	container.NewScope().MustResolve(&other)
+	if a != b || a == other {
+		t.Error("Expected one instance per scope while the function yields Scoped")
+	}
+
+	if err := container.RegisterDynamic(NewTestService, nil); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc for a nil lifetime function, got %v", err)
+	}
+}
+
+// TestInvoke tests calling a function with resolved parameters and telling resolution failures from its own errors
+func TestInvoke(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+
+	var got *TestDependency
+	container.MustInvoke(func(dep *TestDependency) { got = dep })
+	if got == nil {
+		t.Fatal("Expected the parameter to be resolved")
+	}
+
+	failure := errors.New("job failed")
+	err := container.Invoke(func(dep *TestDependency) error { return failure })
+	if err != failure || errors.Is(err, ErrInvokeFailed) {
+		t.Errorf("Expected the function's own error unwrapped, got %v", err)
+	}
+
+	called := false
+	err = container.Invoke(func(svc *TestService) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrInvokeFailed) || !errors.Is(err, ErrServiceNotRegistered) || called {
+		t.Errorf("Expected ErrInvokeFailed without calling fn, got %v (called: %v)", err, called)
+	}
+
+	if err = container.Invoke(func() (int, error) { return 0, nil }); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc for unsupported results, got %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustInvoke to panic on the function's error")
+		}
+	}()
+	container.MustInvoke(func() error { return failure })
+}
+
+// TestGetAsNumeric tests explicit numeric conversion versus strict resolution
+func TestGetAsNumeric(t *testing.T) {
+	container := NewContainer()
+	container.StrictTypes(true)
+	container.MustRegisterInstance(42, Singleton)
+
+	var wide int64
+	if err := container.Resolve(&wide); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected strict resolution to refuse int64 for a registered int, got %v", err)
+	}
+
+	got, err := ContainerGetAs[int64, int](container)
+	if err != nil || got != 42 {
+		t.Errorf("Expected 42 as int64, got %v (%v)", got, err)
+	}
+
+	container.MustRegisterInstance(300.5, Singleton)
+	if _, err = ContainerGetAs[int, float64](container); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected a lossy conversion to fail, got %v", err)
+	}
+	if _, err = ContainerGetAs[int8, int](container); err != nil {
+		t.Errorf("Expected 42 to fit in int8, got %v", err)
+	}
+	if _, err = ContainerGetAs[*TestService, int](container); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed for unrelated types, got %v", err)
+	}
+}
+
+// TestSetMustHandler tests routing Must* failures to a handler instead of panicking
+func TestSetMustHandler(t *testing.T) {
+	container := NewContainer()
+	var handled []error
+	container.SetMustHandler(func(err error) { handled = append(handled, err) })
+
+	var svc *TestService
+	container.MustResolve(&svc)
+	container.MustRegister("not a function", Singleton)
+	container.NewScope().MustResolve(&svc)
+	if got := ScopeMustGet[*TestService](container.NewScope()); got != nil {
+		t.Error("Expected zero value when the handler returns")
+	}
+	ResolverMustGet[*TestService](container.Resolver())
+
+	if len(handled) != 5 {
+		t.Fatalf("Expected 5 handled failures, got %d: %v", len(handled), handled)
+	}
+	if !errors.Is(handled[0], ErrServiceNotRegistered) || !strings.Contains(handled[0].Error(), "[DI Resolution Failed]") {
+		t.Errorf("Expected a prefixed error matching the sentinel, got %v", handled[0])
+	}
+	if !errors.Is(handled[1], ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc, got %v", handled[1])
+	}
+
+	container.SetMustHandler(nil)
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected the default panic after removing the handler")
+		}
+	}()
+	container.MustResolve(&svc)
+}
+
+// TestAnonymousStructParameter tests that an anonymous struct is keyed by its full type identity
+func TestAnonymousStructParameter(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstance(struct {
+		Host string
+		Port int
+	}{Host: "localhost", Port: 8080}, Singleton)
+	// A struct with the same fields but different tags is a different type
+	container.MustRegisterInstance(struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}{Host: "tagged", Port: 1}, Singleton)
+
+	container.MustRegister(func(cfg struct {
+		Host string
+		Port int
+	}) *TestDependency {
+		return &TestDependency{Name: cfg.Host + ":" + strconv.Itoa(cfg.Port)}
+	}, Singleton)
+
+	var dep *TestDependency
+	container.MustResolve(&dep)
+	if dep.Name != "localhost:8080" {
+		t.Errorf("Expected the untagged anonymous struct, got %s", dep.Name)
+	}
+
+	var tagged struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	container.MustResolve(&tagged)
+	if tagged.Host != "tagged" {
+		t.Errorf("Expected the tagged anonymous struct, got %+v", tagged)
+	}
+}
+
+// TestCandidates tests listing the registered concrete implementers of an interface
+func TestCandidates(t *testing.T) {
+	container := NewContainer()
+	if got := ContainerCandidates[ITestInterface](container); len(got) != 0 {
+		t.Errorf("Expected no candidates, got %v", got)
+	}
+
+	container.MustRegister(NewTestImpl, Singleton)
+	container.MustRegister(NewTestService, Singleton)
+	want := []reflect.Type{reflect.TypeOf(&TestImpl{})}
+	if got := ContainerCandidates[ITestInterface](container); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	container.MustRegisterInstanceNamed("b", TestImplB{}, Singleton)
+	container.MustRegisterInstanceNamed("other", NewTestImpl(), Singleton)
+	container.MustRegisterInstanceAs(NewTestImpl(), (*ITestInterface)(nil), Singleton)
+	want = []reflect.Type{reflect.TypeOf(&TestImpl{}), reflect.TypeOf(TestImplB{})}
+	if got := ContainerCandidates[ITestInterface](container); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if got := container.Candidates(reflect.TypeOf(&TestImpl{})); got != nil {
+		t.Errorf("Expected nil for a non-interface type, got %v", got)
+	}
+}
+
+// TestResolveAllConcreteImplementers tests that ResolveAll of an interface includes instances registered as concrete types
+func TestResolveAllConcreteImplementers(t *testing.T) {
+	container := NewContainer()
+	shared := &TestImpl{Value: "shared"}
+	container.MustRegisterInstanceAs(shared, (*ITestInterface)(nil), Singleton)
+	container.MustRegisterInstance(shared, Singleton) // same instance under its concrete type: collected once
+	container.MustRegisterInstanceNamed("b", TestImplB{}, Singleton)
+	container.MustRegisterNamed("scoped", func() *TestImpl { return &TestImpl{Value: "scoped"} }, Scoped)
+
+	var all []ITestInterface
+	if err := container.ResolveAll(&all); err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+	if len(all) != 2 || all[0] != ITestInterface(shared) || all[1].GetValue() != (TestImplB{}).GetValue() {
+		t.Errorf("Expected the interface instance and the concrete TestImplB, got %v", all)
+	}
+
+	// A scope also resolves Scoped implementers
+	var scoped []ITestInterface
+	if err := container.NewScope().ResolveAll(&scoped); err != nil {
+		t.Fatalf("Scope ResolveAll failed: %v", err)
+	}
+	if len(scoped) != 3 || scoped[2].GetValue() != "scoped" {
+		t.Errorf("Expected the Scoped implementer in a scope, got %v", scoped)
+	}
+
+	// Singleton and Transient constructor implementers need IncludeConstructors, like other constructor registrations;
+	// a scope and slice injection follow their ResolveAll
+	container = NewContainer()
+	container.MustRegister(func() *TestImpl { return &TestImpl{Value: "singleton"} }, Singleton)
+	container.MustRegisterNamed("transient", func() TestImplB { return TestImplB{} }, Transient)
+	container.MustRegister(func(impls []ITestInterface) *TestService {
+		return &TestService{Value: strconv.Itoa(len(impls))}
+	}, Transient)
+	var fromRoot, fromScope, withCtors []ITestInterface
+	container.MustResolveAll(&fromRoot)
+	if err := container.NewScope().ResolveAll(&fromScope); err != nil {
+		t.Fatalf("Scope ResolveAll failed: %v", err)
+	}
+	if len(fromRoot) != 0 || len(fromScope) != 0 {
+		t.Errorf("Expected constructor implementers excluded by default, got %v and %v", fromRoot, fromScope)
+	}
+	opts := DefaultResolveAllOptions()
+	opts.IncludeConstructors = true
+	if err := container.ResolveAllOpts(&withCtors, opts); err != nil {
+		t.Fatalf("ResolveAllOpts failed: %v", err)
+	}
+	if len(withCtors) != 2 || withCtors[0].GetValue() != "singleton" || withCtors[1].GetValue() != (TestImplB{}).GetValue() {
+		t.Errorf("Expected both constructor implementers with IncludeConstructors, got %v", withCtors)
+	}
+	var svc *TestService
+	container.MustResolve(&svc)
+	if svc.Value != "0" {
+		t.Errorf("Expected slice injection to follow ResolveAll, got %s implementers", svc.Value)
+	}
+}
+
+// TestResolveAllScopedDependency tests that collections on the root container skip only Scoped registrations, and
+// report an implementer whose own lifetime is fine but which depends on a Scoped service
+func TestResolveAllScopedDependency(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Scoped)
+	container.MustRegisterNamed("scoped", func() *TestImpl { return &TestImpl{Value: "scoped"} }, Scoped)
+	container.MustRegisterInstanceNamed("b", TestImplB{}, Singleton)
+
+	opts := DefaultResolveAllOptions()
+	opts.IncludeConstructors = true
+	var all []ITestInterface
+	if err := container.ResolveAllOpts(&all, opts); err != nil {
+		t.Fatalf("ResolveAllOpts failed: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("Expected the Scoped implementer skipped on the root, got %v", all)
+	}
+
+	container.MustRegister(func(dep *TestDependency) *TestImpl { return &TestImpl{Value: dep.Name} }, Singleton)
+	all = nil
+	if err := container.ResolveAllOpts(&all, opts); !errors.Is(err, ErrScopedOnRootContainer) {
+		t.Errorf("Expected the Scoped dependency reported, got %v (%v)", err, all)
+	}
+	container.MustRegisterGroup("impls", func(dep *TestDependency) *TestImpl { return &TestImpl{Value: dep.Name} }, Singleton)
+	var grouped []ITestInterface
+	if err := container.ResolveGroup("impls", &grouped); !errors.Is(err, ErrScopedOnRootContainer) {
+		t.Errorf("Expected the Scoped dependency reported by the group, got %v", err)
+	}
+}
+
+// TestProvideAliases tests that Provide/ProvideAs and the global Provide/Invoke behave like Register/RegisterAs/Invoke
+func TestProvideAliases(t *testing.T) {
+	container := NewContainer()
+	if err := container.Provide(NewTestService, Singleton); err != nil {
+		t.Fatalf("Provide failed: %v", err)
+	}
+	if err := container.Provide(NewTestService, Singleton); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate like Register, got %v", err)
+	}
+	if err := container.Provide("not a function", Singleton); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc like Register, got %v", err)
+	}
+	if err := container.ProvideAs(NewTestImpl, (*ITestInterface)(nil), Singleton); err != nil {
+		t.Fatalf("ProvideAs failed: %v", err)
+	}
+	var svc *TestService
+	var iface ITestInterface
+	container.MustResolve(&svc)
+	container.MustResolve(&iface)
+	if svc.Value != "test" || iface.GetValue() != "impl" {
+		t.Errorf("Expected provided services to resolve, got %v and %v", svc, iface)
+	}
+
+	GlobalReset()
+	defer GlobalReset()
+	if err := Provide(NewTestService, Singleton); err != nil {
+		t.Fatalf("global Provide failed: %v", err)
+	}
+	var invoked *TestService
+	if err := Invoke(func(s *TestService) { invoked = s }); err != nil || invoked != MustGet[*TestService]() {
+		t.Errorf("Expected global Invoke to receive the global singleton, got %v (%v)", invoked, err)
+	}
+}
+
+// TestResolveFresh tests constructing an independent instance without touching the singleton cache
+func TestResolveFresh(t *testing.T) {
+	container := NewContainer()
+	builds := 0
+	container.MustRegister(func() *TestDependency {
+		builds++
+		return &TestDependency{Name: "dep-" + strconv.Itoa(builds)}
+	}, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Singleton)
+
+	var cached *TestDependency
+	container.MustResolve(&cached)
+	fresh, err := ContainerGetFresh[*TestDependency](container)
+	if err != nil {
+		t.Fatalf("ContainerGetFresh failed: %v", err)
+	}
+	if fresh == cached || fresh.Name != "dep-2" {
+		t.Errorf("Expected a newly constructed instance, got %v", fresh)
+	}
+	var again *TestDependency
+	container.MustResolve(&again)
+	if again != cached {
+		t.Error("Expected the cached singleton to be untouched")
+	}
+
+	// Dependencies of the fresh instance come from the cache
+	var svc *TestServiceWithDep
+	if err = container.ResolveFresh(&svc); err != nil || svc.Dep != cached {
+		t.Errorf("Expected the fresh service to use the cached dependency, got %v (%v)", svc, err)
+	}
+
+	container.MustRegisterInstance(&TestService{}, Singleton)
+	if _, err = ContainerGetFresh[*TestService](container); !errors.Is(err, ErrFreshNotSupported) {
+		t.Errorf("Expected ErrFreshNotSupported for an instance, got %v", err)
+	}
+	if _, err = ContainerGetFresh[*TestImpl](container); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+// TestResolveAllOpts tests the ordering, deduplication and inclusion flags of ResolveAllOpts
+func TestResolveAllOpts(t *testing.T) {
+	container := NewContainer()
+	shared := &TestDependency{Name: "shared"}
+	container.MustRegisterInstance(shared, Singleton)
+	container.MustRegisterInstanceNamed("late", &TestDependency{Name: "late"}, Singleton, WithPriority(-1))
+	container.MustRegisterInstanceNamed("alias", shared, Singleton)
+	container.MustRegisterNamed("built", func() *TestDependency { return &TestDependency{Name: "built"} }, Singleton)
+
+	names := func(opts ResolveAllOptions) []string {
+		var all []*TestDependency
+		if err := container.ResolveAllOpts(&all, opts); err != nil {
+			t.Fatalf("ResolveAllOpts(%+v) failed: %v", opts, err)
+		}
+		out := make([]string, len(all))
+		for i, dep := range all {
+			out[i] = dep.Name
+		}
+		return out
+	}
+
+	tests := []struct {
+		opts ResolveAllOptions
+		want []string
+	}{
+		{ResolveAllOptions{}, []string{"late", "shared"}},
+		{ResolveAllOptions{Ordered: true}, []string{"late", "shared"}},
+		{ResolveAllOptions{IncludeDefault: true}, []string{"shared", "late", "shared"}},
+		{ResolveAllOptions{IncludeDefault: true, Ordered: true}, []string{"late", "shared", "shared"}},
+		{ResolveAllOptions{IncludeDefault: true, Unique: true}, []string{"shared", "late"}},
+		{ResolveAllOptions{IncludeConstructors: true}, []string{"late", "shared", "built"}},
+		{ResolveAllOptions{Ordered: true, Unique: true, IncludeDefault: true, IncludeConstructors: true}, []string{"late", "shared", "built"}},
+	}
+	for _, tt := range tests {
+		if got := names(tt.opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ResolveAllOpts(%+v) = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+
+	// ResolveAll keeps its behavior: DefaultResolveAllOptions
+	var all []*TestDependency
+	container.MustResolveAll(&all)
+	if got := names(DefaultResolveAllOptions()); len(all) != len(got) || all[0].Name != got[0] {
+		t.Errorf("Expected ResolveAll to match DefaultResolveAllOptions, got %d vs %v", len(all), got)
+	}
+
+	// Interface element type: IncludeDefault also governs instances registered by default under a concrete type
+	ifaces := NewContainer()
+	ifaces.MustRegisterInstance(&TestImpl{}, Singleton)
+	ifaces.MustRegisterInstanceNamed("named", &TestImpl{}, Singleton)
+	for _, tt := range []struct {
+		opts ResolveAllOptions
+		want int
+	}{{ResolveAllOptions{}, 1}, {ResolveAllOptions{IncludeDefault: true}, 2}} {
+		var impls []ITestInterface
+		if err := ifaces.ResolveAllOpts(&impls, tt.opts); err != nil || len(impls) != tt.want {
+			t.Errorf("ResolveAllOpts(%+v) on an interface: expected %d, got %d (%v)", tt.opts, tt.want, len(impls), err)
+		}
+	}
+}
+
+// dependencyProvider Custom Provider building *TestDependency
+type dependencyProvider struct {
+	calls int
+	fail  error
+}
+
+func (p *dependencyProvider) Provide(c *Container) (*TestDependency, error) {
+	p.calls++
+	if p.fail != nil {
+		return nil, p.fail
+	}
+	return &TestDependency{Name: "provided-" + strconv.Itoa(p.calls)}, nil
+}
+
+// TestRegisterProvider tests type-safe construction through a Provider
+func TestRegisterProvider(t *testing.T) {
+	container := NewContainer()
+	provider := &dependencyProvider{}
+	if err := ContainerRegisterProvider[*TestDependency](container, provider, Singleton); err != nil {
+		t.Fatalf("ContainerRegisterProvider failed: %v", err)
+	}
+	container.MustRegister(NewTestServiceWithDep, Transient)
+
+	var dep *TestDependency
+	container.MustResolve(&dep)
+	var svc *TestServiceWithDep
+	container.MustResolve(&svc)
+	if dep.Name != "provided-1" || svc.Dep != dep || provider.calls != 1 {
+		t.Errorf("Expected one provided singleton, got %v after %d calls", dep, provider.calls)
+	}
+
+	failing := NewContainer()
+	boom := errors.New("boom")
+	if err := ContainerRegisterProvider[*TestDependency](failing, &dependencyProvider{fail: boom}, Transient); err != nil {
+		t.Fatalf("ContainerRegisterProvider failed: %v", err)
+	}
+	if err := failing.Resolve(&dep); !errors.Is(err, ErrCreateInstanceFailed) || !errors.Is(err, boom) {
+		t.Errorf("Expected ErrCreateInstanceFailed wrapping the provider error, got %v", err)
+	}
+	if err := ContainerRegisterProvider[*TestDependency](failing, nil, Singleton); !errors.Is(err, ErrNotFunc) {
+		t.Errorf("Expected ErrNotFunc for a nil provider, got %v", err)
+	}
+}
+
+// TestResolveGroup tests collecting heterogeneous group members as an interface slice
+func TestResolveGroup(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterGroup("routes", NewTestImpl, Singleton)
+	container.MustRegisterInstanceGroup("routes", TestImplB{}, Singleton)
+	container.MustRegisterGroup("routes", func() *TestImpl { return &TestImpl{Value: "first"} }, Transient, WithPriority(-1))
+	container.MustRegisterGroup("routes", func() *TestService { return &TestService{} }, Scoped)
+
+	var handlers []ITestInterface
+	if err := container.ResolveGroup("routes", &handlers); err != nil {
+		t.Fatalf("ResolveGroup failed: %v", err)
+	}
+	if len(handlers) != 3 || handlers[0].GetValue() != "first" || handlers[1].GetValue() != "impl" || handlers[2].GetValue() != "implB" {
+		t.Errorf("Expected members converted in priority order without the Scoped one, got %v", handlers)
+	}
+
+	typed, err := ContainerGetGroup[ITestInterface](container, "routes")
+	if err != nil || len(typed) != 3 || typed[1] != handlers[1] {
+		t.Errorf("Expected generic group resolution sharing singletons, got %v, %v", typed, err)
+	}
+
+	// A member not implementing the element type fails the conversion
+	container.MustRegisterInstanceGroup("routes", &TestService{}, Singleton)
+	if err := container.ResolveGroup("routes", &handlers); !errors.Is(err, ErrTypeConvertFailed) {
+		t.Errorf("Expected ErrTypeConvertFailed, got %v", err)
+	}
+
+	var none []ITestInterface
+	if err := container.ResolveGroup("missing", &none); err != nil || none == nil || len(none) != 0 {
+		t.Errorf("Expected empty slice for unknown group, got %v, %v", none, err)
+	}
+	if err := container.ResolveGroup("routes", handlers); !errors.Is(err, ErrInvalidOutPtr) {
+		t.Errorf("Expected ErrInvalidOutPtr, got %v", err)
+	}
+	if err := container.RegisterGroup("", NewTestImpl, Singleton); err == nil {
+		t.Error("Expected error for empty group name")
+	}
+
+	// nil and typed nil instances are rejected at registration instead of failing resolution later
+	var nilImpl *TestImpl
+	var nilIface ITestInterface = nilImpl
+	for _, instance := range []any{nil, nilImpl, nilIface} {
+		if err := container.RegisterInstanceGroup("nils", instance, Singleton); !errors.Is(err, ErrNilInstance) {
+			t.Errorf("Expected ErrNilInstance for %#v, got %v", instance, err)
+		}
+	}
+	if err := container.ResolveGroup("nils", &none); err != nil || len(none) != 0 {
+		t.Errorf("Expected nothing registered in the group, got %v, %v", none, err)
+	}
+}
+
+// TestSetNameMatcher tests case-insensitive named lookups and duplicate detection
+func TestSetNameMatcher(t *testing.T) {
+	container := NewContainer()
+	container.SetNameMatcher(strings.EqualFold)
+	container.MustRegisterInstanceNamed("Primary", &TestService{Value: "primary"}, Singleton)
+
+	var svc *TestService
+	if err := container.ResolveNamed("PRIMARY", &svc); err != nil || svc.Value != "primary" {
+		t.Fatalf("Expected case-insensitive resolution, got %v, %v", svc, err)
+	}
+	scope := container.NewScope()
+	if err := scope.ResolveNamed("primary", &svc); err != nil || svc.Value != "primary" {
+		t.Errorf("Expected case-insensitive scope resolution, got %v, %v", svc, err)
+	}
+
+	// Another spelling of a registered name is a duplicate for the same type, and joins it for other types
+	if err := container.RegisterNamed("primary", func() *TestService { return &TestService{} }, Singleton); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	container.MustRegisterNamed("PRIMARY", func() *TestDependency { return &TestDependency{Name: "dep"} }, Singleton)
+	var dep *TestDependency
+	if err := container.ResolveNamed("Primary", &dep); err != nil || dep.Name != "dep" {
+		t.Errorf("Expected registration under the existing name, got %v, %v", dep, err)
+	}
+	var all []*TestService
+	container.MustResolveAll(&all)
+	if len(all) != 1 {
+		t.Errorf("Expected a single named registration, got %d", len(all))
+	}
+
+	// Restoring exact matching
+	container.SetNameMatcher(nil)
+	if err := container.ResolveNamed("primary", &svc); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered with exact matching, got %v", err)
+	}
+}
+
+type cycleA struct {
+	B    *cycleB
+	Name string
+}
+
+type cycleB struct{ A *cycleA }
+
+// TestAllowPointerCycles tests two-phase construction of mutually referencing pointer singletons
+func TestAllowPointerCycles(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func(b *cycleB) *cycleA { return &cycleA{B: b, Name: "a"} }, Singleton)
+	container.MustRegister(func(a *cycleA) *cycleB { return &cycleB{A: a} }, Singleton)
+
+	var a *cycleA
+	if err := container.Resolve(&a); !errors.Is(err, ErrResolveCircularDependency) {
+		t.Fatalf("Expected ErrResolveCircularDependency by default, got %v", err)
+	}
+
+	container.AllowPointerCycles(true)
+	if err := container.Resolve(&a); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if a.Name != "a" || a.B == nil || a.B.A != a {
+		t.Errorf("Expected the dependency to hold the completed singleton, got %+v", a)
+	}
+	var b *cycleB
+	container.MustResolve(&b)
+	if b != a.B {
+		t.Error("Expected cached singletons after two-phase construction")
+	}
+
+	// Resolved through a scope as well, and Transient registrations still report the cycle
+	scoped := NewContainer()
+	scoped.AllowPointerCycles(true)
+	scoped.MustRegister(func(b *cycleB) *cycleA { return &cycleA{B: b} }, Singleton)
+	scoped.MustRegister(func(a *cycleA) *cycleB { return &cycleB{A: a} }, Singleton)
+	if err := scoped.NewScope().Resolve(&b); err != nil || b.A.B != b {
+		t.Errorf("Expected scope two-phase construction, got %+v, %v", b, err)
+	}
+	transient := NewContainer()
+	transient.AllowPointerCycles(true)
+	transient.MustRegister(func(b *cycleB) *cycleA { return &cycleA{B: b} }, Transient)
+	transient.MustRegister(func(a *cycleA) *cycleB { return &cycleB{A: a} }, Transient)
+	if err := transient.Resolve(&a); !errors.Is(err, ErrResolveCircularDependency) {
+		t.Errorf("Expected ErrResolveCircularDependency for Transient, got %v", err)
+	}
+
+	// A failing cyclic constructor leaves no dependency cached with the never-completed instance
+	failing := NewContainer()
+	failing.AllowPointerCycles(true)
+	attempts := 0
+	failing.MustRegister(func(b *cycleB) *cycleA {
+		attempts++
+		if attempts == 1 {
+			panic("not ready")
+		}
+		return &cycleA{B: b, Name: "a"}
+	}, Singleton)
+	failing.MustRegister(func(a *cycleA) *cycleB { return &cycleB{A: a} }, Singleton)
+	if err := failing.Resolve(&a); err == nil {
+		t.Fatal("Expected the constructor error")
+	}
+	if err := failing.Resolve(&b); err != nil || b.A == nil || b.A.Name != "a" || b.A.B != b {
+		t.Errorf("Expected the dependency rebuilt against the completed singleton, got %+v, %v", b, err)
+	}
+}
+
+// TestNamedSliceAndMapInstances tests resolving named slice/map instances by name without sweeping them into
+// element auto-collection
+func TestNamedSliceAndMapInstances(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceNamed("adminRoles", []string{"admin", "root"}, Singleton)
+	container.MustRegisterInstanceNamed("userRoles", []string{"user"}, Singleton)
+	container.MustRegisterInstanceNamed("limits", map[string]int{"rps": 10}, Singleton)
+
+	var adminRoles, userRoles []string
+	container.MustResolveNamed("adminRoles", &adminRoles)
+	container.MustResolveNamed("userRoles", &userRoles)
+	if len(adminRoles) != 2 || adminRoles[0] != "admin" || len(userRoles) != 1 || userRoles[0] != "user" {
+		t.Errorf("Expected named slices by name, got %v, %v", adminRoles, userRoles)
+	}
+	var limits map[string]int
+	container.MustResolveNamed("limits", &limits)
+	if limits["rps"] != 10 {
+		t.Errorf("Expected named map by name, got %v", limits)
+	}
+
+	// Auto-collected []string and map[string]int parameters only gather element-typed registrations
+	var roles []string
+	var counts map[string]int
+	container.MustInvoke(func(r []string, m map[string]int) {
+		roles, counts = r, m
+	})
+	if len(roles) != 0 || len(counts) != 0 {
+		t.Errorf("Expected named slice/map instances kept out of element collection, got %v, %v", roles, counts)
+	}
+
+	// Collections of the slice type itself do gather them, keyed by name for maps
+	var byName map[string][]string
+	container.MustInvoke(func(m map[string][]string) { byName = m })
+	if len(byName) != 2 || byName["userRoles"][0] != "user" {
+		t.Errorf("Expected map of named slices, got %v", byName)
+	}
+}
+
+// recordingStore SingletonStore recording every Set on top of the in-memory store
+type recordingStore struct {
+	*MemorySingletonStore
+	sets []reflect.Type
+}
+
+func (r *recordingStore) Set(t reflect.Type, v reflect.Value) {
+	r.sets = append(r.sets, t)
+	r.MemorySingletonStore.Set(t, v)
+}
+
+// TestSetSingletonStore tests writing constructed singletons to a custom store and adopting stored ones
+func TestSetSingletonStore(t *testing.T) {
+	store := &recordingStore{MemorySingletonStore: NewMemorySingletonStore()}
+	container := NewContainer()
+	container.SetSingletonStore(store)
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	container.MustRegisterNamed("named", func() *TestService { return &TestService{} }, Singleton)
+
+	var svc *TestServiceWithDep
+	container.MustResolve(&svc)
+	container.MustResolve(&svc)
+	var named *TestService
+	container.MustResolveNamed("named", &named)
+	depType := reflect.TypeOf((*TestDependency)(nil))
+	if len(store.sets) != 1 || store.sets[0] != depType {
+		t.Fatalf("Expected a single Set of the default singleton, got %v", store.sets)
+	}
+
+	// A fresh container sharing the store adopts the stored singleton without constructing it
+	restarted := NewContainer()
+	restarted.SetSingletonStore(store)
+	calls := 0
+	restarted.MustRegister(func() *TestDependency { calls++; return &TestDependency{} }, Singleton)
+	var dep *TestDependency
+	restarted.MustResolve(&dep)
+	if calls != 0 || dep != svc.Dep || len(store.sets) != 1 {
+		t.Errorf("Expected the stored singleton to be adopted, got %v after %d constructions", dep, calls)
+	}
+}
+
+// consoleLogger Implements both ILogger and ITestInterface
+type consoleLogger struct{ prefix string }
+
+func (l *consoleLogger) Log(msg string) string { return l.prefix + msg }
+
+func (l *consoleLogger) GetValue() string { return l.prefix }
+
+// TestIndexImplTypes tests resolving the concrete type of a service registered only behind an interface
+func TestIndexImplTypes(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterAs(func() *consoleLogger { return &consoleLogger{prefix: "> "} }, (*ILogger)(nil), Singleton)
+
+	var concrete *consoleLogger
+	if err := container.Resolve(&concrete); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("Expected ErrServiceNotRegistered without the index, got %v", err)
+	}
+
+	container.IndexImplTypes(true)
+	var logger ILogger
+	container.MustResolve(&logger)
+	if err := container.Resolve(&concrete); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if concrete != logger.(*consoleLogger) || !ContainerHas[*consoleLogger](container) {
+		t.Error("Expected the concrete type to resolve the interface singleton")
+	}
+
+	// One instance registered under two interfaces resolves once, two distinct registrations are ambiguous
+	shared := NewContainer()
+	shared.IndexImplTypes(true)
+	instance := &consoleLogger{prefix: "shared"}
+	shared.MustRegisterInstanceAs(instance, (*ILogger)(nil), Singleton)
+	shared.MustRegisterInstanceAs(instance, (*ITestInterface)(nil), Singleton)
+	if err := shared.Resolve(&concrete); err != nil || concrete != instance {
+		t.Errorf("Expected the shared instance, got %v, %v", concrete, err)
+	}
+	distinct := NewContainer()
+	distinct.IndexImplTypes(true)
+	distinct.MustRegisterAs(func() *consoleLogger { return &consoleLogger{} }, (*ILogger)(nil), Singleton)
+	distinct.MustRegisterAs(func() *consoleLogger { return &consoleLogger{} }, (*ITestInterface)(nil), Singleton)
+	if err := distinct.Resolve(&concrete); !errors.Is(err, ErrAmbiguousResolution) {
+		t.Errorf("Expected ErrAmbiguousResolution, got %v", err)
+	}
+}
+
+// TestResolveAllWithNames tests index-aligned registration names with "" for the default registration
+func TestResolveAllWithNames(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterInstanceNamed("replica", &TestService{Value: "replica"}, Singleton, WithPriority(2))
+	container.MustRegisterInstance(&TestService{Value: "default"}, Singleton)
+	container.MustRegisterInstanceNamed("primary", &TestService{Value: "primary"}, Singleton, WithPriority(1))
+
+	for i := 0; i < 5; i++ {
+		var services []*TestService
+		names, err := container.ResolveAllWithNames(&services)
+		if err != nil {
+			t.Fatalf("ResolveAllWithNames failed: %v", err)
+		}
+		if len(names) != 3 || len(services) != 3 {
+			t.Fatalf("Expected 3 aligned entries, got %v / %v", names, services)
+		}
+		want := []string{"", "primary", "replica"}
+		for j, name := range names {
+			if name != want[j] {
+				t.Fatalf("Expected names %v, got %v", want, names)
+			}
+			if value := services[j].Value; (name == "" && value != "default") || (name != "" && value != name) {
+				t.Errorf("Expected %q at index %d, got %q", name, j, value)
+			}
+		}
+	}
+
+	var invalid []*TestService
+	if _, err := container.ResolveAllWithNames(invalid); !errors.Is(err, ErrInvalidOutPtr) {
+		t.Errorf("Expected ErrInvalidOutPtr, got %v", err)
+	}
+}
+
+// selfDescribing Records the ResolutionInfo it was constructed with
+type selfDescribing struct{ Info ResolutionInfo }
+
+// TestResolutionInfo tests filling ResolutionInfo parameters with the metadata of the resolution
+func TestResolutionInfo(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func(info ResolutionInfo) *selfDescribing { return &selfDescribing{Info: info} }, Singleton)
+	container.MustRegister(func(info ResolutionInfo, dep *selfDescribing) *TestService {
+		return &TestService{Value: info.Type.String() + "/" + strconv.Itoa(int(info.Lifetime)) + "/" + strconv.FormatBool(info.InScope)}
+	}, Transient)
+	container.MustRegisterNamed("labeled", func(info ResolutionInfo) *TestDependency { return &TestDependency{Name: info.Name} }, Transient)
+
+	var svc *TestService
+	container.MustResolve(&svc)
+	if svc.Value != "*gofac.TestService/0/false" {
+		t.Errorf("Expected transient info, got %q", svc.Value)
+	}
+	var single *selfDescribing
+	container.MustResolve(&single)
+	if single.Info.Type != reflect.TypeOf(single) || single.Info.Lifetime != Singleton || single.Info.InScope || single.Info.Name != "" {
+		t.Errorf("Expected singleton info of the dependency, got %+v", single.Info)
+	}
+
+	scope := container.NewScope()
+	if err := scope.Resolve(&svc); err != nil || svc.Value != "*gofac.TestService/0/true" {
+		t.Errorf("Expected scope info, got %v, %v", svc, err)
+	}
+	var dep *TestDependency
+	container.MustResolveNamed("labeled", &dep)
+	if dep.Name != "labeled" {
+		t.Errorf("Expected the registration name, got %q", dep.Name)
+	}
+	if err := container.Validate(); err != nil {
+		t.Errorf("Expected ResolutionInfo parameters to validate, got %v", err)
+	}
+}
+
+// TestNilInstanceGuards tests rejecting typed nil instances at registration and nil stored instances at resolution
+func TestNilInstanceGuards(t *testing.T) {
+	container := NewContainer()
+	var nilLogger *TestLogger
+	if err := container.RegisterInstanceAs(nilLogger, (*ILogger)(nil), Singleton); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance for a typed nil, got %v", err)
+	}
+	if err := container.RegisterInstanceNamed("nil", nilLogger, Singleton); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance for a named typed nil, got %v", err)
+	}
+	if err := container.RegisterInstanceImplementation(nilLogger, (*ILogger)(nil), Singleton); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance for a typed nil implementation, got %v", err)
+	}
+	if err := container.NewScope().Override(nilLogger); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance for a typed nil override, got %v", err)
+	}
+	// Nil slices and maps remain valid instances
+	if err := container.RegisterInstance([]string(nil), Singleton); err != nil {
+		t.Errorf("Expected nil slice instance to register, got %v", err)
+	}
+
+	// A stored instance that became a nil pointer is reported instead of handed out
+	container.MustRegisterInstanceAs(&TestLogger{}, (*ILogger)(nil), Singleton)
+	container.MustRegisterInstanceNamed("logger", &TestLogger{}, Singleton)
+	loggerType := reflect.TypeOf((*ILogger)(nil)).Elem()
+	container.services[loggerType].instance = reflect.ValueOf(nilLogger)
+	container.namedServices["logger"][reflect.TypeOf(nilLogger)].instance = reflect.ValueOf(nilLogger)
+
+	var logger ILogger
+	if err := container.Resolve(&logger); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance at resolve, got %v", err)
+	}
+	if err := container.NewScope().Resolve(&logger); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance at scope resolve, got %v", err)
+	}
+	var named *TestLogger
+	if err := container.ResolveNamed("logger", &named); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("Expected ErrNilInstance at named resolve, got %v", err)
+	}
+}
+
+// appProviders Module providers defined as a struct literal
+type appProviders struct {
+	Dependency func() *TestDependency
+	Service    func(dep *TestDependency) *TestServiceWithDep
+	Logger     func() *TestLogger
+	Label      string
+	hidden     func() *TestService
+}
+
+// Settings Method provider using the struct's configuration
+func (p *appProviders) Settings() *TestService { return &TestService{Value: p.Label} }
+
+// String, Close and Reload are not provider-shaped and must not be registered
+func (p *appProviders) String() string { return p.Label }
+func (p *appProviders) Close() error   { return nil }
+func (p *appProviders) Reload()        {}
+
+// TestRegisterProvidersStruct tests registering the function fields and methods of a providers struct
+func TestRegisterProvidersStruct(t *testing.T) {
+	container := NewContainer()
+	providers := &appProviders{
+		Dependency: NewTestDependency,
+		Service:    NewTestServiceWithDep,
+		Logger:     func() *TestLogger { return &TestLogger{} },
+		Label:      "from-struct",
+		hidden:     func() *TestService { return &TestService{} },
+	}
+	if err := container.RegisterProvidersStruct(providers, Singleton); err != nil {
+		t.Fatalf("RegisterProvidersStruct failed: %v", err)
+	}
+
+	var svc *TestServiceWithDep
+	container.MustResolve(&svc)
+	var logger *TestLogger
+	container.MustResolve(&logger)
+	var settings *TestService
+	container.MustResolve(&settings)
+	if svc.Dep == nil || logger == nil || settings.Value != "from-struct" {
+		t.Errorf("Expected every provider registered, got %v, %v, %v", svc, logger, settings)
+	}
+	if _, ok := container.services[reflect.TypeOf("")]; ok {
+		t.Error("Expected non-provider methods to be skipped")
+	}
+
+	// Nil function fields are unset providers and skipped
+	partial := NewContainer()
+	if err := partial.RegisterProvidersStruct(appProviders{Logger: func() *TestLogger { return &TestLogger{} }}, Transient); err != nil {
+		t.Fatalf("Expected nil fields to be skipped, got %v", err)
+	}
+	if len(partial.services) != 1 || partial.services[reflect.TypeOf((*TestLogger)(nil))] == nil {
+		t.Error("Expected only the set field registered")
+	}
+
+	// Every provider is attempted and the failures are aggregated per field
+	err := container.RegisterProvidersStruct(appProviders{Dependency: NewTestDependency, Service: NewTestServiceWithDep}, Singleton)
+	if !errors.Is(err, ErrRegisterDuplicate) || !strings.Contains(err.Error(), "field gofac.appProviders.Dependency") ||
+		!strings.Contains(err.Error(), "field gofac.appProviders.Service") {
+		t.Errorf("Expected aggregated per-field errors, got %v", err)
+	}
+	if err := container.RegisterProvidersStruct(NewTestDependency, Singleton); err == nil {
+		t.Error("Expected error for a non-struct providers value")
+	}
+}
+
+// TestDuplicateInterfaceMessage tests the guided error of a second unnamed registration of an interface
+func TestDuplicateInterfaceMessage(t *testing.T) {
+	container := NewContainer()
+	container.MustRegisterAs(NewTestImpl, (*ITestInterface)(nil), Singleton)
+
+	err := container.RegisterInstanceAs(TestImplB{}, (*ITestInterface)(nil), Singleton)
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Fatalf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	for _, want := range []string{"*gofac.TestImpl", "gofac.TestImplB", "RegisterNamed", "ContainerProvideNamed", "RegisterImplementation"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected message to mention %q, got %v", want, err)
+		}
+	}
+	err = container.RegisterAs(func() *consoleLogger { return &consoleLogger{} }, (*ITestInterface)(nil), Singleton)
+	if !errors.Is(err, ErrRegisterDuplicate) || !strings.Contains(err.Error(), "new implementation: *gofac.consoleLogger") {
+		t.Errorf("Expected both implementations named, got %v", err)
+	}
+
+	// Merge reports a conflicting interface the same way
+	module := NewContainer()
+	module.MustRegisterInstanceAs(TestImplB{}, (*ITestInterface)(nil), Singleton)
+	err = container.Merge(module)
+	if !errors.Is(err, ErrRegisterDuplicate) || !strings.Contains(err.Error(), "new implementation: gofac.TestImplB") {
+		t.Errorf("Expected the guided duplicate error from Merge, got %v", err)
+	}
+
+	// Concrete types keep the short message
+	container.MustRegister(NewTestService, Singleton)
+	if err := container.Register(NewTestService, Singleton); err == nil || strings.Contains(err.Error(), "RegisterImplementation") {
+		t.Errorf("Expected plain duplicate error for a concrete type, got %v", err)
+	}
+}
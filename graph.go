@@ -0,0 +1,197 @@
+package gofac
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// graphNode A registration in the exported dependency diagram
+type graphNode struct {
+	id    string
+	label string
+	def   *ServiceDef // nil for dependencies without a default registration
+}
+
+// graphEdge A dependency edge: from depends on to
+type graphEdge struct {
+	from, to string
+}
+
+// dependencyGraph Snapshot of registrations (default, named and keyed, in registration order) and their dependency edges.
+// Dependencies resolve to the default registration of the parameter type; parameter types without one
+// (auto-collected slices/maps, context.Context, missing services) get a separate unregistered node
+func (c *Container) dependencyGraph() ([]graphNode, []graphEdge) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type entry struct {
+		svcType reflect.Type
+		label   string
+		def     *ServiceDef
+	}
+	entries := make([]entry, 0, len(c.services))
+	for svcType, def := range c.services {
+		if !def.internal {
+			entries = append(entries, entry{svcType, svcType.String(), def})
+		}
+	}
+	for name, namedMap := range c.namedServices {
+		for svcType, def := range namedMap {
+			entries = append(entries, entry{svcType, fmt.Sprintf("%s [%s]", svcType, name), def})
+		}
+	}
+	for id, def := range c.keyedServices {
+		entries = append(entries, entry{def.implType, fmt.Sprintf("%s [key %s]", def.implType, id.(*keyID).name), def})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].def.order < entries[j].def.order })
+
+	nodes := make([]graphNode, 0, len(entries))
+	defaultIDs := make(map[reflect.Type]string, len(c.services))
+	for i, e := range entries {
+		id := fmt.Sprintf("n%d", i)
+		nodes = append(nodes, graphNode{id: id, label: e.label, def: e.def})
+		if c.services[e.svcType] == e.def {
+			defaultIDs[e.svcType] = id
+		}
+	}
+
+	edges := make([]graphEdge, 0)
+	for i, e := range entries {
+		for _, pType := range e.def.dependencyTypes() {
+			to, exists := defaultIDs[pType]
+			if !exists {
+				to = fmt.Sprintf("n%d", len(nodes))
+				nodes = append(nodes, graphNode{id: to, label: pType.String()})
+				defaultIDs[pType] = to
+			}
+			edges = append(edges, graphEdge{from: nodes[i].id, to: to})
+		}
+	}
+	return nodes, edges
+}
+
+// TransitiveDeps Returns the dependency closure of the default registration of t: every type transitively required to
+// construct it, deduplicated and topologically sorted (dependencies before their dependents, t itself excluded), e.g.
+// to warm up a single subsystem. Dependencies follow default registrations and single (or primary) additional
+// implementations; parameter types without one (auto-collected slices/maps, context.Context, missing services) are
+// listed as leaves. A cycle returns ErrResolveCircularDependency naming the chain
+func (c *Container) TransitiveDeps(t reflect.Type) ([]reflect.Type, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if def, _ := c.registrationLocked(t); def == nil {
+		return nil, fmt.Errorf("%w, type: %s", ErrServiceNotRegistered, t)
+	}
+
+	order := make([]reflect.Type, 0)
+	done := make(map[reflect.Type]bool)
+	chain := make([]reflect.Type, 0)
+	var visit func(reflect.Type) error
+	visit = func(svcType reflect.Type) error {
+		if done[svcType] {
+			return nil
+		}
+		for i, onChain := range chain {
+			if onChain == svcType {
+				names := make([]string, 0, len(chain)-i+1)
+				for _, cycled := range chain[i:] {
+					names = append(names, cycled.String())
+				}
+				names = append(names, svcType.String())
+				return fmt.Errorf("%w, circular dependency chain: %s", ErrResolveCircularDependency, strings.Join(names, " -> "))
+			}
+		}
+		if def, _ := c.registrationLocked(svcType); def != nil {
+			chain = append(chain, svcType)
+			for _, pType := range def.dependencyTypes() {
+				if err := visit(pType); err != nil {
+					return err
+				}
+			}
+			chain = chain[:len(chain)-1]
+		}
+		done[svcType] = true
+		order = append(order, svcType)
+		return nil
+	}
+	if err := visit(t); err != nil {
+		return nil, err
+	}
+	return order[:len(order)-1], nil
+}
+
+// lifetimeStyle Lifetime name and fill color used in diagrams
+func lifetimeStyle(scope LifetimeScope) (name, color string) {
+	switch scope {
+	case Singleton:
+		return "Singleton", "#a8d5ff"
+	case Scoped:
+		return "Scoped", "#b8e6b8"
+	default:
+		return "Transient", "#ffe8a3"
+	}
+}
+
+// WriteDOT Writes the dependency diagram in Graphviz DOT format: instances are boxes, constructors/factories ellipses,
+// filled by lifetime; unregistered dependencies are dashed. Output is deterministic (registration order)
+func (c *Container) WriteDOT(w io.Writer) error {
+	nodes, edges := c.dependencyGraph()
+
+	var b strings.Builder
+	b.WriteString("digraph gofac {\n")
+	b.WriteString("\trankdir=LR;\n")
+	for _, n := range nodes {
+		if n.def == nil {
+			fmt.Fprintf(&b, "\t%s [label=%q, style=dashed];\n", n.id, n.label+"\nunregistered")
+			continue
+		}
+		lifetime, color := lifetimeStyle(n.def.scope)
+		shape := "ellipse"
+		if n.def.isInstance {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "\t%s [label=%q, shape=%s, style=filled, fillcolor=%q];\n", n.id, n.label+"\n"+lifetime, shape, color)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, "\t%s -> %s;\n", e.from, e.to)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid Writes the dependency diagram as a Mermaid flowchart: instances are rectangles, constructors/factories
+// rounded, styled by lifetime class; unregistered dependencies are dashed. Output is deterministic (registration order)
+func (c *Container) WriteMermaid(w io.Writer) error {
+	nodes, edges := c.dependencyGraph()
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range nodes {
+		label := strings.ReplaceAll(n.label, `"`, "#quot;")
+		if n.def == nil {
+			fmt.Fprintf(&b, "\t%s[\"%s<br/>unregistered\"]:::unregistered\n", n.id, label)
+			continue
+		}
+		lifetime, _ := lifetimeStyle(n.def.scope)
+		open, closing := "(\"", "\")"
+		if n.def.isInstance {
+			open, closing = "[\"", "\"]"
+		}
+		fmt.Fprintf(&b, "\t%s%s%s<br/>%s%s:::%s\n", n.id, open, label, lifetime, closing, strings.ToLower(lifetime))
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, "\t%s --> %s\n", e.from, e.to)
+	}
+	for _, scope := range []LifetimeScope{Singleton, Scoped, Transient} {
+		lifetime, color := lifetimeStyle(scope)
+		fmt.Fprintf(&b, "\tclassDef %s fill:%s\n", strings.ToLower(lifetime), color)
+	}
+	b.WriteString("\tclassDef unregistered stroke-dasharray:5 5\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
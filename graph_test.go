@@ -0,0 +1,121 @@
+package gofac
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestWriteDOT tests DOT output: node shapes, lifetime colors, named/default duplicates and unregistered dependencies
+func TestWriteDOT(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	container.MustRegisterInstance(&TestService{}, Scoped)
+	container.MustRegisterInstanceNamed("alt", &TestService{}, Singleton)
+	container.MustRegister(func(s []ITestInterface) *TestImpl { return &TestImpl{} }, Singleton)
+
+	var b strings.Builder
+	if err := container.WriteDOT(&b); err != nil {
+		t.Fatalf("WriteDOT failed: %v", err)
+	}
+	expected := `digraph gofac {
+	rankdir=LR;
+	n0 [label="*gofac.TestDependency\nSingleton", shape=ellipse, style=filled, fillcolor="#a8d5ff"];
+	n1 [label="*gofac.TestServiceWithDep\nTransient", shape=ellipse, style=filled, fillcolor="#ffe8a3"];
+	n2 [label="*gofac.TestService\nScoped", shape=box, style=filled, fillcolor="#b8e6b8"];
+	n3 [label="*gofac.TestService [alt]\nSingleton", shape=box, style=filled, fillcolor="#a8d5ff"];
+	n4 [label="*gofac.TestImpl\nSingleton", shape=ellipse, style=filled, fillcolor="#a8d5ff"];
+	n5 [label="[]gofac.ITestInterface\nunregistered", style=dashed];
+	n1 -> n0;
+	n4 -> n5;
+}
+`
+	if b.String() != expected {
+		t.Errorf("Unexpected DOT output:\n%s", b.String())
+	}
+
+	// Output is deterministic across calls
+	var again strings.Builder
+	_ = container.WriteDOT(&again)
+	if again.String() != b.String() {
+		t.Error("Expected deterministic DOT output")
+	}
+}
+
+// TestWriteMermaid tests Mermaid output
+func TestWriteMermaid(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Scoped)
+	container.MustRegisterInstance(&TestService{}, Singleton)
+
+	var b strings.Builder
+	if err := container.WriteMermaid(&b); err != nil {
+		t.Fatalf("WriteMermaid failed: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"flowchart LR\n",
+		"\tn0(\"*gofac.TestDependency<br/>Singleton\"):::singleton\n",
+		"\tn1(\"*gofac.TestServiceWithDep<br/>Scoped\"):::scoped\n",
+		"\tn2[\"*gofac.TestService<br/>Singleton\"]:::singleton\n",
+		"\tn1 --> n0\n",
+		"\tclassDef singleton fill:#a8d5ff\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected Mermaid output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+type depLeaf struct{}
+type depLeft struct{ Leaf *depLeaf }
+type depRight struct{ Leaf *depLeaf }
+type depRoot struct {
+	Left  *depLeft
+	Right *depRight
+}
+
+// TestTransitiveDeps tests the deduplicated, topologically sorted dependency closure of a multi-level graph
+func TestTransitiveDeps(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func(l *depLeft, r *depRight) *depRoot { return &depRoot{l, r} }, Singleton)
+	container.MustRegister(func(leaf *depLeaf) *depRight { return &depRight{leaf} }, Transient)
+	container.MustRegister(func(leaf *depLeaf, ctx context.Context) *depLeft { return &depLeft{leaf} }, Transient)
+	container.MustRegisterInstance(&depLeaf{}, Singleton)
+
+	deps, err := container.TransitiveDeps(reflect.TypeOf(&depRoot{}))
+	if err != nil {
+		t.Fatalf("TransitiveDeps failed: %v", err)
+	}
+	leafType, leftType, rightType := reflect.TypeOf(&depLeaf{}), reflect.TypeOf(&depLeft{}), reflect.TypeOf(&depRight{})
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	expected := []reflect.Type{leafType, ctxType, leftType, rightType}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, deps)
+	}
+	for i := range expected {
+		if deps[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, deps)
+			break
+		}
+	}
+
+	if deps, err := container.TransitiveDeps(leafType); err != nil || len(deps) != 0 {
+		t.Errorf("Expected no dependencies for an instance, got %v, %v", deps, err)
+	}
+	if _, err := container.TransitiveDeps(reflect.TypeOf(&TestService{})); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+
+	cyclic := NewContainer()
+	cyclic.MustRegister(func(r *depRight) *depLeft { return &depLeft{} }, Singleton)
+	cyclic.MustRegister(func(l *depLeft) *depRight { return &depRight{} }, Singleton)
+	_, err = cyclic.TransitiveDeps(leftType)
+	if !errors.Is(err, ErrResolveCircularDependency) || !strings.Contains(err.Error(), "*gofac.depLeft -> *gofac.depRight -> *gofac.depLeft") {
+		t.Errorf("Expected the cycle to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package gofac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Lifecycle Services implementing this interface are started/stopped by Container.Start/Container.Stop
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+var lifecycleType = reflect.TypeOf((*Lifecycle)(nil)).Elem()
+
+// Start Resolves all singletons implementing Lifecycle and calls Start in dependency order (dependencies first).
+// If a Start fails, startup is aborted and the already-started services are stopped in reverse order.
+// Calling Start again before Stop is a no-op, so no service is started twice.
+func (c *Container) Start(ctx context.Context) error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	if c.running {
+		return nil
+	}
+
+	services, err := c.lifecycleServices()
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range services {
+		if err := svc.Start(ctx); err != nil {
+			startErr := fmt.Errorf("failed to start %T: %w", svc, err)
+			return errors.Join(startErr, c.stopStarted(ctx))
+		}
+		c.started = append(c.started, svc)
+	}
+	c.running = true
+	return nil
+}
+
+// Stop Calls Stop on all services started by Start in reverse order; every service is stopped and errors are aggregated
+func (c *Container) Stop(ctx context.Context) error {
+	c.lifecycleMu.Lock()
+	defer c.lifecycleMu.Unlock()
+	c.running = false
+	return c.stopStarted(ctx)
+}
+
+// stopStarted Stops started services in reverse order and clears the list (caller holds lifecycleMu)
+func (c *Container) stopStarted(ctx context.Context) error {
+	var errs []error
+	for i := len(c.started) - 1; i >= 0; i-- {
+		if err := c.started[i].Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %T: %w", c.started[i], err))
+		}
+	}
+	c.started = nil
+	return errors.Join(errs...)
+}
+
+// mayBeLifecycle Whether a registration can resolve to a Lifecycle singleton: its implementation type implements
+// Lifecycle, or is an interface (factory of an interface type) whose dynamic type is only known once built
+func mayBeLifecycle(serviceDef *ServiceDef) bool {
+	if serviceDef.scope != Singleton || serviceDef.implType == nil {
+		return false
+	}
+	return serviceDef.implType.Implements(lifecycleType) || serviceDef.implType.Kind() == reflect.Interface
+}
+
+// lifecycleServices Resolves singleton Lifecycle services: default registrations in dependency order,
+// followed by named registrations sorted by name; the same instance is only returned once. Singletons that cannot
+// be a Lifecycle are not resolved, so Start does not build unrelated services eagerly
+func (c *Container) lifecycleServices() ([]Lifecycle, error) {
+	c.mu.RLock()
+	defaults := make([]reflect.Type, 0, len(c.services))
+	for svcType := range c.services {
+		defaults = append(defaults, svcType)
+	}
+	sort.Slice(defaults, func(i, j int) bool {
+		return c.services[defaults[i]].order < c.services[defaults[j]].order
+	})
+
+	// Depth-first topological order over constructor parameters
+	ordered := make([]reflect.Type, 0, len(defaults))
+	visited := make(map[reflect.Type]bool)
+	var visit func(svcType reflect.Type)
+	visit = func(svcType reflect.Type) {
+		serviceDef, exists := c.services[svcType]
+		if !exists || visited[svcType] {
+			return
+		}
+		visited[svcType] = true
+		for _, pType := range serviceDef.dependencyTypes() {
+			visit(pType)
+		}
+		ordered = append(ordered, svcType)
+	}
+	for _, svcType := range defaults {
+		visit(svcType)
+	}
+
+	names := make([]string, 0, len(c.namedServices))
+	for name := range c.namedServices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	c.mu.RUnlock()
+
+	result := make([]Lifecycle, 0)
+	seen := make(map[any]bool)
+	add := func(instance reflect.Value) {
+		if !instance.IsValid() || !instance.Type().Implements(lifecycleType) {
+			return
+		}
+		svc := instance.Interface().(Lifecycle)
+		if instance.Kind() == reflect.Ptr {
+			if seen[svc] {
+				return
+			}
+			seen[svc] = true
+		}
+		result = append(result, svc)
+	}
+
+	for _, svcType := range ordered {
+		c.mu.RLock()
+		serviceDef := c.services[svcType]
+		c.mu.RUnlock()
+		if !mayBeLifecycle(serviceDef) {
+			continue
+		}
+		track := newResolveTrack(nil)
+		instance, err := c.resolve(svcType, track)
+		track.release()
+		if err != nil {
+			return nil, err
+		}
+		add(instance)
+	}
+
+	for _, name := range names {
+		c.mu.RLock()
+		defs := make([]*ServiceDef, 0, len(c.namedServices[name]))
+		for _, serviceDef := range c.namedServices[name] {
+			if mayBeLifecycle(serviceDef) {
+				defs = append(defs, serviceDef)
+			}
+		}
+		c.mu.RUnlock()
+		sort.Slice(defs, func(i, j int) bool { return defs[i].order < defs[j].order })
+		for _, serviceDef := range defs {
+			instance, err := c.resolveNamedDef(serviceDef)
+			if err != nil {
+				return nil, err
+			}
+			add(instance)
+		}
+	}
+	return result, nil
+}
+
+// disposal A resource released by Container.Close/Scope.Dispose
+type disposal struct {
+	order    int
+	svcType  reflect.Type
+	cleanup  func()
+	instance reflect.Value
+}
+
+// release Runs the cleanup callback, or closes a constructed instance implementing io.Closer
+func (d disposal) release() error {
+	if d.cleanup != nil {
+		d.cleanup()
+		return nil
+	}
+	if closer, ok := d.instance.Interface().(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("failed to close %s: %w", d.svcType, err)
+		}
+	}
+	return nil
+}
+
+// disposalOf The disposal of a registration and its resolved instance, false when there is nothing to release:
+// instances are released only through their cleanup (they are owned by the caller), constructed ones through io.Closer
+func disposalOf(svcType reflect.Type, serviceDef *ServiceDef, instance reflect.Value) (disposal, bool) {
+	if serviceDef.cleanup != nil {
+		return disposal{order: serviceDef.order, svcType: svcType, cleanup: serviceDef.cleanup}, true
+	}
+	if serviceDef.isInstance || !instance.IsValid() || !instance.CanInterface() {
+		return disposal{}, false
+	}
+	if _, ok := instance.Interface().(io.Closer); !ok {
+		return disposal{}, false
+	}
+	return disposal{order: serviceDef.order, svcType: svcType, instance: instance}, true
+}
+
+// releaseAll Releases disposals in reverse registration order; every one is attempted and errors are aggregated
+func releaseAll(disposals []disposal) error {
+	sort.Slice(disposals, func(i, j int) bool { return disposals[i].order > disposals[j].order })
+	var errs []error
+	for _, d := range disposals {
+		if err := d.release(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close Disposes the named scopes (NamedScope), then releases Singletons in reverse registration order: cleanup
+// callbacks of RegisterInstanceWithCleanup run (for instances of any lifetime), and constructed singletons implementing
+// io.Closer are closed (pre-registered instances are owned by the caller and only released through a cleanup). Every
+// release is attempted and errors are aggregated
+func (c *Container) Close() error {
+	c.mu.Lock()
+	namedScopes := c.namedScopes
+	c.namedScopes = nil
+	c.mu.Unlock()
+	tags := make([]string, 0, len(namedScopes))
+	for tag := range namedScopes {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	var errs []error
+	for _, tag := range tags {
+		if err := namedScopes[tag].Dispose(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.mu.RLock()
+	disposals := make([]disposal, 0)
+	add := func(svcType reflect.Type, serviceDef *ServiceDef) {
+		if serviceDef.scope != Singleton && !serviceDef.isInstance {
+			return
+		}
+		if d, ok := disposalOf(svcType, serviceDef, serviceDef.instance); ok {
+			disposals = append(disposals, d)
+		}
+	}
+	for svcType, serviceDef := range c.services {
+		add(svcType, serviceDef)
+	}
+	for _, namedMap := range c.namedServices {
+		for svcType, serviceDef := range namedMap {
+			add(svcType, serviceDef)
+		}
+	}
+	for _, serviceDef := range c.keyedServices {
+		add(serviceDef.implType, serviceDef)
+	}
+	for svcType, impls := range c.implementations {
+		for _, serviceDef := range impls {
+			add(svcType, serviceDef)
+		}
+	}
+	for _, members := range c.groups {
+		for _, serviceDef := range members {
+			add(serviceDef.implType, serviceDef)
+		}
+	}
+	c.mu.RUnlock()
+	return errors.Join(append(errs, releaseAll(disposals))...)
+}
+
+// Dispose Releases the Scoped instances built by this scope in reverse registration order (io.Closer for constructed
+// instances; instances inherited through Clone stay with their owner, and pre-registered instances shared by every
+// scope are released by Container.Close) and empties the scope
+func (s *Scope) Dispose() error {
+	// NewScopeContext scope: end its context so the watcher does not dispose again
+	if s.cancel != nil {
+		s.ctxDone.Store(true)
+		s.cancel()
+	}
+
+	s.mu.Lock()
+	scopedInst, inherited, scopedDefs := s.scopedInst, s.inherited, s.scopedDefs
+	s.scopedInst = make(map[reflect.Type]reflect.Value)
+	s.inherited = nil
+	s.scopedDefs = nil
+	s.mu.Unlock()
+
+	s.root.mu.RLock()
+	disposals := make([]disposal, 0)
+	for svcType, instance := range scopedInst {
+		serviceDef, exists := s.root.services[svcType]
+		if !exists || inherited[svcType] || serviceDef.scope != Scoped || serviceDef.isInstance {
+			continue
+		}
+		if d, ok := disposalOf(svcType, serviceDef, instance); ok {
+			disposals = append(disposals, d)
+		}
+	}
+	for serviceDef, instance := range scopedDefs {
+		if serviceDef.isInstance {
+			continue
+		}
+		if d, ok := disposalOf(serviceDef.implType, serviceDef, instance); ok {
+			disposals = append(disposals, d)
+		}
+	}
+	s.root.mu.RUnlock()
+	return releaseAll(disposals)
+}
+
+// NewScopeContext Creates a scope tied to ctx: it is disposed automatically once ctx is done, so a request scope needs no
+// manual defer Dispose. The returned context is derived from ctx and also ends when the scope is disposed manually; the
+// automatic disposal happens at most once and its errors are discarded (call Dispose to observe them)
+func (c *Container) NewScopeContext(ctx context.Context) (*Scope, context.Context) {
+	scopeCtx, cancel := context.WithCancel(ctx)
+	scope := c.NewScope()
+	scope.cancel = cancel
+	go func() {
+		<-scopeCtx.Done()
+		if scope.ctxDone.CompareAndSwap(false, true) {
+			_ = scope.Dispose()
+		}
+	}()
+	return scope, scopeCtx
+}
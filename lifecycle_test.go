@@ -0,0 +1,324 @@
+package gofac
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Lifecycle test types: the server depends on the database, so the database must start first and stop last
+type lifecycleRecorder struct {
+	events []string
+}
+
+type TestDatabase struct {
+	rec      *lifecycleRecorder
+	startErr error
+}
+
+func (d *TestDatabase) Start(ctx context.Context) error {
+	d.rec.events = append(d.rec.events, "start:db")
+	return d.startErr
+}
+
+func (d *TestDatabase) Stop(ctx context.Context) error {
+	d.rec.events = append(d.rec.events, "stop:db")
+	return nil
+}
+
+type TestServer struct {
+	rec      *lifecycleRecorder
+	db       *TestDatabase
+	startErr error
+}
+
+func (s *TestServer) Start(ctx context.Context) error {
+	s.rec.events = append(s.rec.events, "start:server")
+	return s.startErr
+}
+
+func (s *TestServer) Stop(ctx context.Context) error {
+	s.rec.events = append(s.rec.events, "stop:server")
+	return nil
+}
+
+func newLifecycleContainer(rec *lifecycleRecorder, dbErr, serverErr error) *Container {
+	container := NewContainer()
+	// Register the dependent first so that registration order alone would be wrong
+	container.MustRegister(func(db *TestDatabase) *TestServer {
+		return &TestServer{rec: rec, db: db, startErr: serverErr}
+	}, Singleton)
+	container.MustRegister(func() *TestDatabase {
+		return &TestDatabase{rec: rec, startErr: dbErr}
+	}, Singleton)
+	container.MustRegister(NewTestService, Singleton) // not a Lifecycle
+	return container
+}
+
+// TestLifecycleStartStopOrder tests that Start follows dependency order and Stop runs in reverse
+func TestLifecycleStartStopOrder(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	container := newLifecycleContainer(rec, nil, nil)
+
+	if err := container.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	expected := []string{"start:db", "start:server", "stop:server", "stop:db"}
+	if len(rec.events) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, rec.events)
+	}
+	for i := range expected {
+		if rec.events[i] != expected[i] {
+			t.Errorf("Event %d: expected '%s', got '%s'", i, expected[i], rec.events[i])
+		}
+	}
+
+	// Stopping again is a no-op
+	rec.events = nil
+	if err := container.Stop(context.Background()); err != nil || len(rec.events) != 0 {
+		t.Errorf("Second Stop should do nothing, got %v / %v", err, rec.events)
+	}
+}
+
+// TestLifecycleStartOnlyLifecycle tests that Start builds only Lifecycle singletons and that a second Start is a no-op
+func TestLifecycleStartOnlyLifecycle(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	container := newLifecycleContainer(rec, nil, nil)
+	built := 0
+	container.MustRegisterNamed("unrelated", func() *TestDependency {
+		built++
+		return &TestDependency{}
+	}, Singleton)
+
+	if err := container.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if built != 0 {
+		t.Errorf("Start should not build non-Lifecycle singletons, built %d", built)
+	}
+	if err := container.Start(context.Background()); err != nil {
+		t.Fatalf("Second Start failed: %v", err)
+	}
+	if len(rec.events) != 2 {
+		t.Errorf("Second Start should not start services again, got %v", rec.events)
+	}
+
+	rec.events = nil
+	if err := container.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	expected := []string{"stop:server", "stop:db"}
+	if len(rec.events) != len(expected) || rec.events[0] != expected[0] || rec.events[1] != expected[1] {
+		t.Errorf("Expected each service stopped once %v, got %v", expected, rec.events)
+	}
+
+	// After Stop the container can be started again
+	rec.events = nil
+	if err := container.Start(context.Background()); err != nil || len(rec.events) != 2 {
+		t.Errorf("Restart should start services again, got %v / %v", err, rec.events)
+	}
+}
+
+// TestLifecycleStartFailure tests that a failed Start aborts and stops already-started services
+func TestLifecycleStartFailure(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	startErr := errors.New("port in use")
+	container := newLifecycleContainer(rec, nil, startErr)
+
+	err := container.Start(context.Background())
+	if !errors.Is(err, startErr) {
+		t.Fatalf("Expected start error, got %v", err)
+	}
+
+	expected := []string{"start:db", "start:server", "stop:db"}
+	if len(rec.events) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, rec.events)
+	}
+	for i := range expected {
+		if rec.events[i] != expected[i] {
+			t.Errorf("Event %d: expected '%s', got '%s'", i, expected[i], rec.events[i])
+		}
+	}
+}
+
+// testCloser Records Close calls in TestContainerCloseCleanup
+type testCloser struct {
+	name   string
+	events *[]string
+}
+
+func (c *testCloser) Close() error {
+	*c.events = append(*c.events, "close:"+c.name)
+	return nil
+}
+
+// TestContainerCloseCleanup tests that Close runs cleanups and closes constructed singletons in reverse registration order
+func TestContainerCloseCleanup(t *testing.T) {
+	var events []string
+	container := NewContainer()
+	container.MustRegisterInstanceWithCleanup(&TestDependency{Name: "first"}, Singleton, func() {
+		events = append(events, "cleanup:first")
+	})
+	container.MustRegister(func() *testCloser { return &testCloser{name: "built", events: &events} }, Singleton)
+	container.MustRegisterInstanceWithCleanup(&TestService{Value: "second"}, Singleton, func() {
+		events = append(events, "cleanup:second")
+	})
+	// Pre-registered closers are owned by the caller and not closed
+	container.MustRegisterInstanceNamed("owned", &testCloser{name: "owned", events: &events}, Singleton)
+
+	var closer *testCloser
+	container.MustResolve(&closer)
+	if err := container.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	expected := []string{"cleanup:second", "close:built", "cleanup:first"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+
+	if err := container.RegisterInstanceWithCleanup(&TestImpl{}, Singleton, nil); err == nil {
+		t.Error("Expected an error for a nil cleanup")
+	}
+	if err := container.RegisterInstanceWithCleanup(&TestImpl{}, Transient, func() {}); !errors.Is(err, ErrTransientInstance) {
+		t.Errorf("Expected ErrTransientInstance, got %v", err)
+	}
+}
+
+// TestScopeDisposeCleanup tests that Dispose releases the scope's own Scoped instances only
+func TestScopeDisposeCleanup(t *testing.T) {
+	var events []string
+	container := NewContainer()
+	container.MustRegisterInstanceWithCleanup(&TestDependency{Name: "scoped"}, Scoped, func() {
+		events = append(events, "cleanup:scoped")
+	})
+	container.MustRegister(func(d *TestDependency) *testCloser { return &testCloser{name: "built", events: &events} }, Scoped)
+	container.MustRegisterInstanceWithCleanup(&TestService{}, Singleton, func() {
+		events = append(events, "cleanup:singleton")
+	})
+
+	unused := container.NewScope()
+	scope := container.NewScope()
+	var closer *testCloser
+	scope.MustResolve(&closer)
+	clone := scope.Clone()
+
+	if err := unused.Dispose(); err != nil || len(events) != 0 {
+		t.Fatalf("Expected nothing to release in an unused scope, got %v (%v)", events, err)
+	}
+	if err := clone.Dispose(); err != nil || len(events) != 0 {
+		t.Fatalf("Expected inherited instances to stay with their owner, got %v (%v)", events, err)
+	}
+	if err := scope.Dispose(); err != nil {
+		t.Fatalf("Dispose failed: %v", err)
+	}
+	// The pre-registered Scoped instance is shared by every scope: its cleanup is left to Container.Close
+	expected := []string{"close:built"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected %v, got %v", expected, events)
+	}
+
+	// The scope is emptied: resolving again builds a new instance
+	var again *testCloser
+	scope.MustResolve(&again)
+	if again == closer {
+		t.Error("Expected a fresh Scoped instance after Dispose")
+	}
+
+	other := container.NewScope()
+	other.MustResolve(&closer)
+	if err := other.Dispose(); err != nil {
+		t.Fatalf("Dispose failed: %v", err)
+	}
+	events = nil
+	if err := container.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	expected = []string{"cleanup:singleton", "cleanup:scoped"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected each cleanup to run once on Close %v, got %v", expected, events)
+	}
+}
+
+// TestNamedScopeDispose tests that DisposeNamedScope and Container.Close dispose named scopes
+func TestNamedScopeDispose(t *testing.T) {
+	var events []string
+	container := NewContainer()
+	container.MustRegister(func() *testCloser { return &testCloser{name: "tenant", events: &events} }, Scoped)
+
+	var closer *testCloser
+	container.NamedScope("tenant:acme").MustResolve(&closer)
+	container.NamedScope("tenant:globex").MustResolve(&closer)
+	if ok, err := container.DisposeNamedScope("tenant:acme"); !ok || err != nil {
+		t.Fatalf("Expected tenant:acme to be disposed, got %v, %v", ok, err)
+	}
+	if !reflect.DeepEqual(events, []string{"close:tenant"}) {
+		t.Errorf("Expected the acme instance closed, got %v", events)
+	}
+
+	events = nil
+	if err := container.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !reflect.DeepEqual(events, []string{"close:tenant"}) {
+		t.Errorf("Expected Close to dispose the remaining named scope, got %v", events)
+	}
+	if ok, _ := container.DisposeNamedScope("tenant:globex"); ok {
+		t.Error("Expected Close to remove the named scopes")
+	}
+}
+
+// signalCloser Reports each Close on a channel (closed from another goroutine in TestNewScopeContext)
+type signalCloser struct {
+	closed chan string
+	name   string
+}
+
+func (c *signalCloser) Close() error {
+	c.closed <- c.name
+	return nil
+}
+
+// TestNewScopeContext tests that a scope is disposed once its context is done, and only once
+func TestNewScopeContext(t *testing.T) {
+	closed := make(chan string, 4)
+	container := NewContainer()
+	container.MustRegister(func() *signalCloser { return &signalCloser{closed: closed, name: "scoped"} }, Scoped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scope, scopeCtx := container.NewScopeContext(ctx)
+	var closer *signalCloser
+	scope.MustResolve(&closer)
+
+	cancel()
+	select {
+	case name := <-closed:
+		if name != "scoped" {
+			t.Errorf("Expected the scoped closer, got %s", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the scope to be disposed when its context is done")
+	}
+	if scopeCtx.Err() == nil {
+		t.Error("Expected the scope context to be done")
+	}
+
+	// Manual disposal ends the scope context and the watcher does not dispose again
+	manual, manualCtx := container.NewScopeContext(context.Background())
+	manual.MustResolve(&closer)
+	if err := manual.Dispose(); err != nil {
+		t.Fatalf("Dispose failed: %v", err)
+	}
+	<-closed
+	<-manualCtx.Done()
+	select {
+	case name := <-closed:
+		t.Errorf("Expected a single disposal, got another close of %s", name)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,259 @@
+package gofac
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// In Embed in a struct used as constructor parameter to have each exported field resolved individually instead of
+// the struct itself. Field tags: `name:"x"` resolves a named service, `optional:"true"` leaves the field at its zero
+// value when the service is not registered.
+//
+//	type HandlerParams struct {
+//	    gofac.In
+//	    Repo   *UserRepo
+//	    Cache  ICache `name:"redis"`
+//	    Tracer ITracer `optional:"true"`
+//	}
+type In struct{}
+
+// Out Embed in a struct returned by a constructor to register each exported field as its own service (with the
+// constructor's lifetime; `name:"x"` registers the field as a named service). Fields of one Singleton/Scoped result
+// share a single constructor call; for Transient every field resolution calls the constructor again.
+//
+//	type RepoResult struct {
+//	    gofac.Out
+//	    Users  *UserRepo
+//	    Orders *OrderRepo
+//	}
+type Out struct{}
+
+var (
+	inType  = reflect.TypeOf(In{})
+	outType = reflect.TypeOf(Out{})
+)
+
+// isInStruct Whether t is a struct embedding In
+func isInStruct(t reflect.Type) bool {
+	return embedsMarker(t, inType)
+}
+
+// isOutStruct Whether t is a struct embedding Out
+func isOutStruct(t reflect.Type) bool {
+	return embedsMarker(t, outType)
+}
+
+func embedsMarker(t reflect.Type, marker reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// markerFields Exported fields of an In/Out struct, excluding the embedded marker
+func markerFields(t reflect.Type, marker reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type == marker || !field.IsExported() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// inDependencies Default-registration dependencies declared by an In struct (named and optional fields excluded)
+func inDependencies(t reflect.Type) []reflect.Type {
+	deps := make([]reflect.Type, 0)
+	for _, field := range markerFields(t, inType) {
+		if field.Tag.Get("name") == "" && field.Tag.Get("optional") != "true" {
+			deps = append(deps, field.Type)
+		}
+	}
+	return deps
+}
+
+// resolveInStruct Builds an In struct parameter by resolving each exported field (by type through resolveParams,
+// so scopes and slice/map auto-collection apply, or by name through the root container)
+func resolveInStruct(root *Container, t reflect.Type, resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error), track *resolveTrack) (reflect.Value, error) {
+	value := reflect.New(t).Elem()
+	for _, field := range markerFields(t, inType) {
+		fieldVal := value.FieldByIndex(field.Index)
+		var err error
+		if name := field.Tag.Get("name"); name != "" {
+			err = root.ResolveNamed(name, fieldVal.Addr().Interface())
+		} else {
+			var resolved []reflect.Value
+			if resolved, err = resolveParams([]reflect.Type{field.Type}, track); err == nil {
+				fieldVal.Set(resolved[0])
+			}
+		}
+		if err != nil {
+			if field.Tag.Get("optional") == "true" && errors.Is(err, ErrServiceNotRegistered) {
+				continue
+			}
+			return reflect.Value{}, fmt.Errorf("failed to resolve field %s.%s: %w", t, field.Name, err)
+		}
+	}
+	return value, nil
+}
+
+// registerOutFields Registers every exported field of an Out struct as a service derived from the registered struct
+// (caller holds c.mu). Conflicts are checked up front so a failing Out registration registers no field
+func (c *Container) registerOutFields(structType reflect.Type, scope LifetimeScope) error {
+	fields := markerFields(structType, outType)
+	for _, field := range fields {
+		name := field.Tag.Get("name")
+		if name == "" {
+			if _, exists := c.services[field.Type]; exists {
+				return fmt.Errorf("%w, type: %s (field %s.%s)", ErrRegisterDuplicate, field.Type, structType, field.Name)
+			}
+		} else if _, exists := c.namedServices[c.namedKeyLocked(name)][field.Type]; exists {
+			return fmt.Errorf("%w, name: %s, type: %s (field %s.%s)", ErrRegisterDuplicate, name, field.Type, structType, field.Name)
+		}
+	}
+
+	for _, field := range fields {
+		index := field.Index
+		extractType := reflect.FuncOf([]reflect.Type{structType}, []reflect.Type{field.Type}, false)
+		extract := reflect.MakeFunc(extractType, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{args[0].FieldByIndex(index)}
+		}).Interface()
+
+		// Interface fields are registered as the interface itself
+		var interfaceType any
+		if field.Type.Kind() == reflect.Interface {
+			interfaceType = reflect.New(field.Type).Interface()
+		}
+		var err error
+		if name := field.Tag.Get("name"); name != "" {
+			err = c.registerNamed(name, extract, interfaceType, scope, nil)
+		} else {
+			err = c.register(extract, interfaceType, scope)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to register field %s.%s: %w", structType, field.Name, err)
+		}
+	}
+	return nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RegisterMulti Registers every non-error return value of a multi-return constructor as its own service, e.g.
+// func NewPair() (*Reader, *Writer). All results come from a single constructor call per lifetime instance (shared for
+// Singleton/Scoped). A trailing error result is reported as ErrCreateInstanceFailed. Interface results require RegisterMultiAs
+func (c *Container) RegisterMulti(ctor any, scope LifetimeScope) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerMulti(ctor, scope, false)
+}
+
+// RegisterMultiAs Same as RegisterMulti, additionally allowing interface results (registered as that interface)
+func (c *Container) RegisterMultiAs(ctor any, scope LifetimeScope) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.registerMulti(ctor, scope, true)
+}
+
+// registerMulti Wraps the constructor into one returning an Out tuple struct (fields R0..Rn) and registers it
+func (c *Container) registerMulti(ctor any, scope LifetimeScope, allowInterfaces bool) error {
+	ctorVal := reflect.ValueOf(ctor)
+	if ctorVal.Kind() != reflect.Func {
+		return ErrNotFunc
+	}
+	ctorType := ctorVal.Type()
+
+	numResults := ctorType.NumOut()
+	returnsError := numResults > 0 && ctorType.Out(numResults-1) == errorType
+	if returnsError {
+		numResults--
+	}
+	if numResults == 0 {
+		return fmt.Errorf("%w, constructor has no service results", ErrNoReturn)
+	}
+
+	fields := []reflect.StructField{{Name: "Out", Type: outType, Anonymous: true}}
+	seen := make(map[reflect.Type]bool, numResults)
+	for i := 0; i < numResults; i++ {
+		resultType := ctorType.Out(i)
+		if resultType.Kind() == reflect.Interface && !allowInterfaces {
+			return fmt.Errorf("%w, result %d is interface: %s (use RegisterMultiAs)", ErrNotConcreteType, i, resultType)
+		}
+		if seen[resultType] {
+			return fmt.Errorf("%w, constructor returns %s more than once", ErrRegisterDuplicate, resultType)
+		}
+		seen[resultType] = true
+		fields = append(fields, reflect.StructField{Name: fmt.Sprintf("R%d", i), Type: resultType})
+	}
+	tupleType := reflect.StructOf(fields)
+
+	ins := make([]reflect.Type, ctorType.NumIn())
+	for i := range ins {
+		ins[i] = ctorType.In(i)
+	}
+	// The wrapper returns (tuple, error): a constructor error fails the resolution like any fallible constructor
+	wrapperType := reflect.FuncOf(ins, []reflect.Type{tupleType, errorType}, ctorType.IsVariadic())
+	wrapper := reflect.MakeFunc(wrapperType, func(args []reflect.Value) []reflect.Value {
+		var results []reflect.Value
+		if ctorType.IsVariadic() {
+			results = ctorVal.CallSlice(args)
+		} else {
+			results = ctorVal.Call(args)
+		}
+		tuple := reflect.New(tupleType).Elem()
+		if returnsError && !results[numResults].IsNil() {
+			return []reflect.Value{tuple, results[numResults]}
+		}
+		for i := 0; i < numResults; i++ {
+			tuple.Field(i + 1).Set(results[i])
+		}
+		return []reflect.Value{tuple, reflect.Zero(errorType)}
+	})
+
+	// The tuple is an implementation detail: hidden from introspection, its fields report the constructor's dependencies
+	_, tupleDef, err := newFuncServiceDef(wrapper.Interface(), nil, scope, true)
+	if err != nil {
+		return err
+	}
+	tupleDef.internal = true
+	if err := c.addDefaultLocked(tupleType, tupleDef, true); err != nil {
+		return err
+	}
+	for i := 0; i < numResults; i++ {
+		c.services[ctorType.Out(i)].via = tupleDef
+	}
+	return nil
+}
+
+// RegisterWithRetry Registers a constructor returning T or (T, error) whose error results are retried: it is called up
+// to attempts times, sleeping backoff between attempts, before resolution fails with ErrCreateInstanceFailed carrying
+// the attempt count and the last error. Meant for services dialing a remote (typically Singleton); panics are not retried
+func (c *Container) RegisterWithRetry(ctor any, scope LifetimeScope, attempts int, backoff time.Duration) error {
+	ctorVal := reflect.ValueOf(ctor)
+	if ctorVal.Kind() != reflect.Func {
+		return ErrNotFunc
+	}
+	if attempts < 1 {
+		return fmt.Errorf("retry attempts must be at least 1, got %d", attempts)
+	}
+	ctorType := ctorVal.Type()
+	returnsError := ctorType.NumOut() == 2 && ctorType.Out(1) == errorType
+	svcType, serviceDef, err := newFuncServiceDef(ctor, nil, scope, returnsError)
+	if err != nil {
+		return err
+	}
+	serviceDef.retry = &retryPolicy{attempts: attempts, backoff: backoff}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.addDefaultLocked(svcType, serviceDef, true)
+}
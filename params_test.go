@@ -0,0 +1,288 @@
+package gofac
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type HandlerParams struct {
+	In
+	Service *TestService
+	Dep     *TestDependency `name:"special"`
+	Impl    ITestInterface  `optional:"true"`
+	Missing *TestImpl       `optional:"true"`
+	hidden  *TestService
+}
+
+type ParamsHandler struct {
+	Params HandlerParams
+}
+
+type RepoResult struct {
+	Out
+	Service *TestService
+	Impl    ITestInterface
+	Dep     *TestDependency `name:"primary"`
+}
+
+// TestInStructParameter tests that In struct fields are resolved by type, by name and optionally
+func TestInStructParameter(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegisterInstanceNamed("special", &TestDependency{Name: "special"}, Singleton)
+	container.MustRegister(func(p HandlerParams) *ParamsHandler { return &ParamsHandler{Params: p} }, Transient)
+
+	var handler *ParamsHandler
+	if err := container.Resolve(&handler); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	var svc *TestService
+	container.MustResolve(&svc)
+	if handler.Params.Service != svc {
+		t.Error("Expected field resolved by type")
+	}
+	if handler.Params.Dep == nil || handler.Params.Dep.Name != "special" {
+		t.Error("Expected field resolved by name")
+	}
+	if handler.Params.Impl != nil || handler.Params.Missing != nil || handler.Params.hidden != nil {
+		t.Error("Expected optional and unexported fields to stay zero")
+	}
+
+	// Required field missing
+	container = NewContainer()
+	container.MustRegister(func(p HandlerParams) *ParamsHandler { return &ParamsHandler{Params: p} }, Transient)
+	if err := container.Resolve(&handler); !errors.Is(err, ErrServiceNotRegistered) {
+		t.Errorf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+	if dangling := container.DanglingServices(); len(dangling) != 1 {
+		t.Errorf("Expected In struct dependencies to be checked statically, got %v", dangling)
+	}
+}
+
+// TestOutStructResult tests that each Out struct field is registered as a service sharing one constructor call
+func TestOutStructResult(t *testing.T) {
+	container := NewContainer()
+	calls := 0
+	container.MustRegister(func() RepoResult {
+		calls++
+		return RepoResult{
+			Service: &TestService{Value: "out"},
+			Impl:    NewTestImpl(),
+			Dep:     &TestDependency{Name: "primary"},
+		}
+	}, Singleton)
+
+	var svc *TestService
+	container.MustResolve(&svc)
+	var impl ITestInterface
+	container.MustResolve(&impl)
+	var dep *TestDependency
+	if err := container.ResolveNamed("primary", &dep); err != nil {
+		t.Fatalf("ResolveNamed failed: %v", err)
+	}
+	if svc.Value != "out" || impl.GetValue() != "impl" || dep.Name != "primary" {
+		t.Error("Expected Out fields to be registered as services")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single constructor call for Singleton Out result, got %d", calls)
+	}
+
+	// Conflicting field: nothing from the Out result is registered
+	container = NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	err := container.Register(func() RepoResult { return RepoResult{} }, Singleton)
+	if !errors.Is(err, ErrRegisterDuplicate) {
+		t.Fatalf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	if container.isRegistered(reflect.TypeOf((*ITestInterface)(nil)).Elem()) || container.isRegistered(reflect.TypeOf(RepoResult{})) {
+		t.Error("Expected no partial registration of a conflicting Out result")
+	}
+}
+
+// TestOutStructScoped tests that Scoped Out fields share one constructor call per scope
+func TestOutStructScoped(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(func() RepoResult {
+		return RepoResult{Service: &TestService{}, Impl: NewTestImpl(), Dep: &TestDependency{}}
+	}, Scoped)
+
+	scope1, scope2 := container.NewScope(), container.NewScope()
+	var a1, a2, b *TestService
+	scope1.MustResolve(&a1)
+	scope1.MustResolve(&a2)
+	scope2.MustResolve(&b)
+	if a1 != a2 || a1 == b {
+		t.Error("Expected Out fields to follow the Scoped lifetime")
+	}
+}
+
+type MultiReader struct{ id int }
+type MultiWriter struct{ id int }
+
+// TestRegisterMulti tests that one constructor call populates every result of a multi-return constructor
+func TestRegisterMulti(t *testing.T) {
+	container := NewContainer()
+	calls := 0
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegisterMulti(func(dep *TestDependency) (*MultiReader, *MultiWriter) {
+		calls++
+		return &MultiReader{id: calls}, &MultiWriter{id: calls}
+	}, Singleton)
+
+	var r1, r2 *MultiReader
+	var w *MultiWriter
+	container.MustResolve(&r1)
+	container.MustResolve(&w)
+	container.MustResolve(&r2)
+	if calls != 1 {
+		t.Fatalf("Expected a single constructor call, got %d", calls)
+	}
+	if r1 != r2 || r1.id != w.id {
+		t.Error("Expected both results to come from the same constructor call")
+	}
+
+	// Scoped: one call per scope
+	container = NewContainer()
+	container.MustRegisterMulti(func() (*MultiReader, *MultiWriter) {
+		return &MultiReader{}, &MultiWriter{}
+	}, Scoped)
+	scope1, scope2 := container.NewScope(), container.NewScope()
+	var a, b *MultiReader
+	scope1.MustResolve(&a)
+	scope2.MustResolve(&b)
+	if a == b {
+		t.Error("Expected Scoped results to differ between scopes")
+	}
+
+	// The synthetic result tuple stays internal: graphs and dependency queries see the constructor's own dependencies
+	container = NewContainer()
+	container.MustRegister(NewTestService, Scoped)
+	container.MustRegisterMulti(func(svc *TestService, deps ...*TestDependency) (*MultiReader, *MultiWriter) {
+		return &MultiReader{id: len(deps)}, &MultiWriter{}
+	}, Singleton)
+	var dot strings.Builder
+	if err := container.WriteDOT(&dot); err != nil || strings.Contains(dot.String(), "gofac.Out") {
+		t.Errorf("Expected no tuple node in the graph, got %v:\n%s", err, dot.String())
+	}
+	deps, err := container.TransitiveDeps(reflect.TypeOf((*MultiReader)(nil)))
+	if err != nil || len(deps) != 2 || deps[0] != reflect.TypeOf((*TestService)(nil)) {
+		t.Errorf("Expected the constructor's dependencies, got %v, %v", deps, err)
+	}
+	if err := container.Validate(); !errors.Is(err, ErrCaptiveDependency) || strings.Contains(err.Error(), "gofac.Out") {
+		t.Errorf("Expected captive dependencies reported on the results only, got %v", err)
+	}
+
+	// A variadic constructor receives its auto-collected slice
+	container = NewContainer()
+	container.MustRegisterInstanceNamed("first", &TestDependency{}, Singleton)
+	container.MustRegisterMulti(func(deps ...*TestDependency) (*MultiReader, *MultiWriter) {
+		return &MultiReader{id: len(deps)}, &MultiWriter{}
+	}, Singleton)
+	var reader *MultiReader
+	if err := container.Resolve(&reader); err != nil || reader.id != 1 {
+		t.Errorf("Expected the variadic constructor called with the collected slice, got %v, %v", reader, err)
+	}
+}
+
+// TestRegisterMultiError tests error results, interface validation and duplicate results
+func TestRegisterMultiError(t *testing.T) {
+	container := NewContainer()
+	boom := errors.New("boom")
+	container.MustRegisterMulti(func() (*MultiReader, *MultiWriter, error) {
+		return nil, nil, boom
+	}, Transient)
+	var r *MultiReader
+	err := container.Resolve(&r)
+	if !errors.Is(err, ErrCreateInstanceFailed) || !errors.Is(err, boom) {
+		t.Errorf("Expected ErrCreateInstanceFailed wrapping the constructor error, got %v", err)
+	}
+
+	err = container.RegisterMulti(func() (ITestInterface, *MultiWriter) { return nil, nil }, Singleton)
+	if !errors.Is(err, ErrNotConcreteType) {
+		t.Errorf("Expected ErrNotConcreteType, got %v", err)
+	}
+	if err = container.RegisterMulti(func() (*TestService, *TestService) { return nil, nil }, Singleton); !errors.Is(err, ErrRegisterDuplicate) {
+		t.Errorf("Expected ErrRegisterDuplicate, got %v", err)
+	}
+	if err = container.RegisterMulti(func() error { return nil }, Singleton); !errors.Is(err, ErrNoReturn) {
+		t.Errorf("Expected ErrNoReturn, got %v", err)
+	}
+
+	// RegisterMultiAs allows interface results
+	container = NewContainer()
+	if err = container.RegisterMultiAs(func() (ITestInterface, *MultiWriter) { return NewTestImpl(), &MultiWriter{} }, Singleton); err != nil {
+		t.Fatalf("RegisterMultiAs failed: %v", err)
+	}
+	var impl ITestInterface
+	container.MustResolve(&impl)
+	if impl.GetValue() != "impl" {
+		t.Error("Expected interface result to be resolvable")
+	}
+}
+
+func TestRegisterWithRetry(t *testing.T) {
+	container := NewContainer()
+	calls := 0
+	container.MustRegisterWithRetry(func() (*TestDependency, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("connection refused")
+		}
+		return &TestDependency{Name: "remote"}, nil
+	}, Singleton, 3, time.Millisecond)
+
+	var dep *TestDependency
+	container.MustResolve(&dep)
+	if dep.Name != "remote" || calls != 3 {
+		t.Errorf("Expected success on the third attempt, got %v after %d calls", dep, calls)
+	}
+
+	refused := errors.New("connection refused")
+	calls = 0
+	container = NewContainer()
+	container.MustRegisterWithRetry(func() (*TestDependency, error) {
+		calls++
+		return nil, refused
+	}, Singleton, 2, 0)
+	err := container.Resolve(&dep)
+	if !errors.Is(err, ErrCreateInstanceFailed) || !errors.Is(err, refused) || !strings.Contains(err.Error(), "2 attempts") || calls != 2 {
+		t.Errorf("Expected ErrCreateInstanceFailed after 2 attempts, got %v after %d calls", err, calls)
+	}
+
+	if err = container.RegisterWithRetry(func() *TestService { return nil }, Singleton, 0, 0); err == nil {
+		t.Error("Expected an error for zero attempts")
+	}
+	if err = container.RegisterWithRetry(func() (*TestService, *TestDependency) { return nil, nil }, Singleton, 1, 0); !errors.Is(err, ErrNoReturn) {
+		t.Errorf("Expected ErrNoReturn, got %v", err)
+	}
+
+	// The backoff ends as soon as the resolution context is done
+	calls = 0
+	container = NewContainer()
+	container.MustRegisterWithRetry(func() (*TestDependency, error) {
+		calls++
+		return nil, refused
+	}, Transient, 5, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = container.ResolveContext(ctx, &dep)
+	if !errors.Is(err, ErrResolveCanceled) || !errors.Is(err, context.DeadlineExceeded) || calls != 1 || time.Since(start) > time.Minute {
+		t.Errorf("Expected the backoff canceled by the context, got %v after %d calls", err, calls)
+	}
+
+	// Variadic constructors receive their auto-collected slice
+	container = NewContainer()
+	container.MustRegisterInstanceNamed("dep", &TestDependency{Name: "dep"}, Singleton)
+	container.MustRegisterWithRetry(func(deps ...*TestDependency) (*TestService, error) {
+		return &TestService{Value: deps[0].Name}, nil
+	}, Singleton, 2, 0)
+	var svc *TestService
+	if err = container.Resolve(&svc); err != nil || svc.Value != "dep" {
+		t.Errorf("Expected the variadic constructor resolved, got %v, %v", svc, err)
+	}
+}
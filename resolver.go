@@ -0,0 +1,114 @@
+package gofac
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Resolver Read-only view of a container: it can resolve services but not register them, e.g. for request handlers
+type Resolver interface {
+	Resolve(out any) error
+	ResolveNamed(name string, out any) error
+	ResolveAll(out any, opts ...ResolveAllOption) error
+}
+
+// containerResolver Thin wrapper so the view cannot be type-asserted back to *Container
+type containerResolver struct {
+	c *Container
+}
+
+func (r containerResolver) Resolve(out any) error { return r.c.Resolve(out) }
+
+func (r containerResolver) ResolveNamed(name string, out any) error {
+	return r.c.ResolveNamed(name, out)
+}
+
+func (r containerResolver) ResolveAll(out any, opts ...ResolveAllOption) error {
+	return r.c.ResolveAll(out, opts...)
+}
+
+// Resolver Returns a read-only view of the container that exposes resolution only
+func (c *Container) Resolver() Resolver {
+	return containerResolver{c: c}
+}
+
+// ResolverGet Generic resolution through a Resolver
+func ResolverGet[T any](r Resolver) (T, error) {
+	var result T
+	err := r.Resolve(&result)
+	return result, err
+}
+
+// ResolverMustGet Generic resolution through a Resolver, panics on error (or calls the SetMustHandler handler of the
+// container behind a view returned by Container.Resolver or injected into a constructor)
+func ResolverMustGet[T any](r Resolver) T {
+	result, err := ResolverGet[T](r)
+	if err != nil {
+		switch view := r.(type) {
+		case containerResolver:
+			view.c.mustFail("", err)
+		case *inflightResolver:
+			view.root.mustFail("", err)
+		default:
+			panic(err)
+		}
+	}
+	return result
+}
+
+var resolverType = reflect.TypeOf((*Resolver)(nil)).Elem()
+
+// inflightResolver Resolver injected into a constructor (or init method) parameter of type Resolver: Resolve shares the
+// ongoing resolution's state, so dependencies resolved manually mid-construction take part in circular dependency
+// detection and use the same container or scope (Scoped caches included). It must not be used concurrently with that
+// resolution; once the resolution has finished it keeps working with fresh state. ResolveNamed and ResolveAll go
+// through the root container like Container.Resolver
+type inflightResolver struct {
+	root          *Container
+	resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error)
+	mu            sync.Mutex
+	track         *resolveTrack // Ongoing resolution, nil once it has finished
+}
+
+// inflightResolver Creates a Resolver view bound to this resolution, detached when the track is released
+func (t *resolveTrack) inflightResolver(root *Container, resolveParams func([]reflect.Type, *resolveTrack) ([]reflect.Value, error)) reflect.Value {
+	r := &inflightResolver{root: root, resolveParams: resolveParams, track: t}
+	t.resolvers = append(t.resolvers, r)
+	var view Resolver = r
+	return reflect.ValueOf(&view).Elem()
+}
+
+// detach Unbinds the view from its finished resolution (the track is about to be reused)
+func (r *inflightResolver) detach() {
+	r.mu.Lock()
+	r.track = nil
+	r.mu.Unlock()
+}
+
+func (r *inflightResolver) Resolve(out any) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return ErrInvalidOutPtr
+	}
+	r.mu.Lock()
+	track := r.track
+	r.mu.Unlock()
+	if track == nil {
+		track = newResolveTrack(nil)
+		defer track.release()
+	}
+	values, err := r.resolveParams([]reflect.Type{outVal.Elem().Type()}, track)
+	if err != nil {
+		return err
+	}
+	outVal.Elem().Set(values[0])
+	return nil
+}
+
+func (r *inflightResolver) ResolveNamed(name string, out any) error {
+	return r.root.ResolveNamed(name, out)
+}
+
+func (r *inflightResolver) ResolveAll(out any, opts ...ResolveAllOption) error {
+	return r.root.ResolveAll(out, opts...)
+}
@@ -0,0 +1,89 @@
+package gofac
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestResolverView tests that the read-only view resolves but exposes no registration methods
+func TestResolverView(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestService, Singleton)
+	container.MustRegisterInstanceNamed("named", &TestService{Value: "named"}, Singleton)
+
+	resolver := container.Resolver()
+	var svc *TestService
+	if err := resolver.Resolve(&svc); err != nil || svc.Value != "test" {
+		t.Errorf("Expected Resolve through the view, got %v (%v)", svc, err)
+	}
+	var named *TestService
+	if err := resolver.ResolveNamed("named", &named); err != nil || named.Value != "named" {
+		t.Errorf("Expected ResolveNamed through the view, got %v (%v)", named, err)
+	}
+	var all []*TestService
+	if err := resolver.ResolveAll(&all); err != nil || len(all) != 1 {
+		t.Errorf("Expected ResolveAll through the view, got %v (%v)", all, err)
+	}
+	if got := ResolverMustGet[*TestService](resolver); got != svc {
+		t.Error("Expected ResolverMustGet to return the singleton")
+	}
+
+	// Neither the interface nor the concrete view carries registration methods, nor is it a *Container
+	for _, typ := range []reflect.Type{reflect.TypeOf((*Resolver)(nil)).Elem(), reflect.TypeOf(resolver)} {
+		for i := 0; i < typ.NumMethod(); i++ {
+			if name := typ.Method(i).Name; strings.HasPrefix(name, "Register") || strings.HasPrefix(name, "Must") {
+				t.Errorf("Expected no registration methods on %s, found %s", typ, name)
+			}
+		}
+	}
+	if _, ok := resolver.(interface {
+		Register(any, LifetimeScope) error
+	}); ok {
+		t.Error("Expected the view not to expose Register")
+	}
+}
+
+// TestInflightResolver tests resolving manually from within a constructor through an injected Resolver
+func TestInflightResolver(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Scoped)
+	container.MustRegister(func(r Resolver) *TestServiceWithDep {
+		return &TestServiceWithDep{Dep: ResolverMustGet[*TestDependency](r)}
+	}, Scoped)
+
+	// The nested resolve uses the same scope: the Scoped dependency is shared
+	scope := container.NewScope()
+	var svc *TestServiceWithDep
+	scope.MustResolve(&svc)
+	var dep *TestDependency
+	scope.MustResolve(&dep)
+	if svc.Dep != dep {
+		t.Error("Expected the manually resolved dependency to come from the resolving scope")
+	}
+
+	// A cycle routed through the injected Resolver is detected
+	cyclic := NewContainer()
+	cyclic.MustRegister(func(r Resolver) *TestService {
+		var self *TestService
+		if err := r.Resolve(&self); !errors.Is(err, ErrResolveCircularDependency) {
+			t.Errorf("Expected ErrResolveCircularDependency, got %v", err)
+		}
+		return &TestService{Value: "built"}
+	}, Singleton)
+	var built *TestService
+	cyclic.MustResolve(&built)
+
+	// Retained past construction, the view keeps working with fresh state
+	var kept Resolver
+	container.MustRegister(func(r Resolver) *TestImpl {
+		kept = r
+		return NewTestImpl()
+	}, Transient)
+	var impl *TestImpl
+	container.MustResolve(&impl)
+	if _, err := ResolverGet[*TestImpl](kept); err != nil {
+		t.Errorf("Expected the retained view to resolve after construction, got %v", err)
+	}
+}
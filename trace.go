@@ -0,0 +1,81 @@
+package gofac
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Trace Node of a resolution trace (ResolveTraced): one resolution by type, whether it was served from a cache or
+// pre-registered instance, how long it took (including its dependencies) and the resolutions it triggered
+type Trace struct {
+	Type      reflect.Type
+	FromCache bool
+	Children  []Trace
+	Dur       time.Duration
+
+	start time.Time
+}
+
+// String Renders the trace as an indented tree, one resolution per line
+func (t Trace) String() string {
+	var b strings.Builder
+	t.write(&b, 0)
+	return b.String()
+}
+
+func (t Trace) write(b *strings.Builder, depth int) {
+	origin := "constructed"
+	if t.FromCache {
+		origin = "cached"
+	}
+	fmt.Fprintf(b, "%s%s (%s, %s)\n", strings.Repeat("  ", depth), t.Type, origin, t.Dur)
+	for _, child := range t.Children {
+		child.write(b, depth+1)
+	}
+}
+
+// beginTrace Opens a trace node for svcType under the current node; no-op (nil, nil) unless tracing
+func (t *resolveTrack) beginTrace(svcType reflect.Type) (parent, node *Trace) {
+	if t.trace == nil {
+		return nil, nil
+	}
+	parent = t.trace
+	node = &Trace{Type: svcType, start: time.Now()}
+	t.trace = node
+	return parent, node
+}
+
+// endTrace Completes a node opened by beginTrace and attaches it to its parent
+func (t *resolveTrack) endTrace(parent, node *Trace, fromCache bool) {
+	if node == nil {
+		return
+	}
+	node.Dur = time.Since(node.start)
+	node.FromCache = fromCache
+	parent.Children = append(parent.Children, *node)
+	t.trace = parent
+}
+
+// ResolveTraced Same as Resolve, additionally returning the tree of resolutions it performed (constructed vs cached,
+// with timings) for debugging slow or unexpected construction. On failure the partial trace is returned with the error
+func (c *Container) ResolveTraced(out any) (Trace, error) {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr || outVal.IsNil() {
+		return Trace{}, ErrInvalidOutPtr
+	}
+	svcType := outVal.Elem().Type()
+
+	track := newResolveTrack(nil)
+	defer track.release()
+	root := &Trace{}
+	track.trace = root
+	instance, err := c.resolve(svcType, track)
+	trace := root.Children[0]
+	if err != nil {
+		return trace, err
+	}
+	outVal.Elem().Set(instance)
+	return trace, nil
+}
@@ -0,0 +1,70 @@
+package gofac
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestResolveTraced tests the construction tree of a small graph, constructed vs cached
+func TestResolveTraced(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestDependency, Singleton)
+	container.MustRegister(NewTestServiceWithDep, Transient)
+	container.MustRegister(func(a *TestServiceWithDep, b *TestDependency) *TestService {
+		return &TestService{}
+	}, Transient)
+
+	var svc *TestService
+	trace, err := container.ResolveTraced(&svc)
+	if err != nil {
+		t.Fatalf("ResolveTraced failed: %v", err)
+	}
+	if svc == nil {
+		t.Fatal("Expected the service to be resolved")
+	}
+
+	// *TestService -> (*TestServiceWithDep -> *TestDependency), *TestDependency (now cached)
+	if trace.Type != reflect.TypeOf(svc) || trace.FromCache || len(trace.Children) != 2 {
+		t.Fatalf("Unexpected root node: %+v", trace)
+	}
+	withDep, dep := trace.Children[0], trace.Children[1]
+	if withDep.Type != reflect.TypeOf(&TestServiceWithDep{}) || withDep.FromCache || len(withDep.Children) != 1 {
+		t.Errorf("Unexpected first child: %+v", withDep)
+	}
+	if inner := withDep.Children[0]; inner.Type != reflect.TypeOf(&TestDependency{}) || inner.FromCache {
+		t.Errorf("Expected the singleton to be constructed first, got %+v", inner)
+	}
+	if dep.Type != reflect.TypeOf(&TestDependency{}) || !dep.FromCache || len(dep.Children) != 0 {
+		t.Errorf("Expected the singleton to be cached the second time, got %+v", dep)
+	}
+	if trace.Dur < withDep.Dur {
+		t.Error("Expected a node's duration to include its children")
+	}
+
+	lines := strings.Split(strings.TrimSpace(trace.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "*gofac.TestService (constructed, ") ||
+		!strings.HasPrefix(lines[2], "    *gofac.TestDependency (constructed, ") ||
+		!strings.HasPrefix(lines[3], "  *gofac.TestDependency (cached, ") {
+		t.Errorf("Unexpected trace rendering:\n%s", trace)
+	}
+}
+
+// TestResolveTracedFailure tests that a failing resolution returns the partial trace
+func TestResolveTracedFailure(t *testing.T) {
+	container := NewContainer()
+	container.MustRegister(NewTestServiceWithDep, Transient)
+
+	var svc *TestServiceWithDep
+	trace, err := container.ResolveTraced(&svc)
+	if !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("Expected ErrServiceNotRegistered, got %v", err)
+	}
+	if len(trace.Children) != 1 || trace.Children[0].Type != reflect.TypeOf(&TestDependency{}) {
+		t.Errorf("Expected the failed dependency in the partial trace, got %+v", trace)
+	}
+	if _, err := container.ResolveTraced(svc); !errors.Is(err, ErrInvalidOutPtr) {
+		t.Errorf("Expected ErrInvalidOutPtr, got %v", err)
+	}
+}